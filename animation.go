@@ -3,16 +3,112 @@ package main
 import (
 	"context"
 	"cubik/api"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// defaultFrameDuration is how long each frame is shown when an animation
+// does not specify its own timing.
+const defaultFrameDuration = time.Second
+
+// PlaybackMode is the order in which an animation walks its frames.
+type PlaybackMode int
+
+const (
+	// PlaybackForward plays the frames first to last, then starts over.
+	PlaybackForward PlaybackMode = iota
+	// PlaybackPingPong plays the frames first to last and back again.
+	PlaybackPingPong
+)
+
 type AnimationState struct {
 	DeviceLocation string
 	Frames         [][]Color
+	// FrameDuration is how long each frame is shown. Zero means
+	// defaultFrameDuration.
+	FrameDuration time.Duration
+	// FrameDurations optionally sets how long each frame is shown, one entry
+	// per frame. It overrides FrameDuration unless its length differs from
+	// the number of frames.
+	FrameDurations []time.Duration
+	Mode           PlaybackMode
+	// LoopCount is how many passes over the frames are played before the
+	// animation stops by itself. Zero loops until stopped.
+	LoopCount int
+	// TransitionSteps is how many intermediate frames crossfade each frame
+	// into the next one, spread evenly over the frame duration and limited by
+	// the maximum frame rate. Zero switches frames without a transition.
+	TransitionSteps int
+	// Preview marks an ephemeral animation that plays its frames once and
+	// stops. Previews are not reported as the device's running animation.
+	Preview bool
+	// ClearOnStop blanks the matrix when the animation is stopped, instead
+	// of leaving the last frame sent, which may be halfway through a
+	// transition, on the cube. Animations that finish by themselves keep
+	// their final frame.
+	ClearOnStop bool
+	// PausedForPower is set while the device reports being switched off;
+	// frames are not sent until it is switched back on.
+	PausedForPower atomic.Bool
 	StopFunc       func()
+
+	meter    atomic.Pointer[fpsMeter]
+	throttle atomic.Pointer[adaptiveThrottle]
+	// frameCount is the number of frames being played, which for a
+	// playlist changes with every item while Frames stays the first's.
+	frameCount   atomic.Int64
+	frameIndex   atomic.Int64
+	paused       atomic.Bool
+	pauseChanged chan struct{}
+}
+
+// ErrNoAnimation is returned when a device has no running animation to act on.
+var ErrNoAnimation = errors.New("no animation running")
+
+// ErrAnimationRunning is returned when a preview is started on a device that
+// is playing an animation.
+var ErrAnimationRunning = errors.New("an animation is already running on the device")
+
+// Paused reports whether playback was paused with PauseDeviceAnimation.
+func (s *AnimationState) Paused() bool {
+	return s.paused.Load()
+}
+
+// setPaused updates the paused flag and wakes the playback loop if it changed.
+func (s *AnimationState) setPaused(paused bool) {
+	if s.paused.Swap(paused) == paused {
+		return
+	}
+	select {
+	case s.pauseChanged <- struct{}{}:
+	default:
+	}
+}
+
+// EffectiveFPS returns the frame rate measured since playback of the current
+// frames started, or 0 before the first frame was sent.
+func (s *AnimationState) EffectiveFPS() float64 {
+	meter := s.meter.Load()
+	if meter == nil {
+		return 0
+	}
+	return meter.FPS()
+}
+
+// AdaptiveFPS returns the frame rate the adaptive throttle currently targets,
+// or 0 when nothing is playing.
+func (s *AnimationState) AdaptiveFPS() float64 {
+	throttle := s.throttle.Load()
+	if throttle == nil {
+		return 0
+	}
+	return throttle.FPS()
 }
 
 var (
@@ -32,67 +128,520 @@ func ConvertAPIFrameToColors(apiFrame []api.RGBPixel) []Color {
 	return colors
 }
 
+// maxAnimationFrames bounds the number of frames of an animation, keeping
+// the memory a single request or saved animation can take in check.
+const maxAnimationFrames = 1000
+
+// validateFrames checks that there are between one and maxAnimationFrames
+// frames and that every frame covers exactly pixelCount pixels, so no stale
+// pixels bleed between frames.
+func validateFrames(frames [][]Color, pixelCount int) error {
+	if len(frames) == 0 {
+		return errors.New("animation has no frames")
+	}
+	if len(frames) > maxAnimationFrames {
+		return fmt.Errorf("animation has %d frames, at most %d are allowed", len(frames), maxAnimationFrames)
+	}
+	for i, frame := range frames {
+		if len(frame) != pixelCount {
+			return fmt.Errorf("frame %d has %d pixels, expected %d", i, len(frame), pixelCount)
+		}
+	}
+	return nil
+}
+
 func PlayAnimation(ctx context.Context, state *AnimationState) error {
-	deviceInfo := &DeviceInfo{Location: state.DeviceLocation}
+	item := PlaylistItem{Frames: state.Frames, FrameDuration: state.FrameDuration, FrameDurations: state.FrameDurations}
+	if err := validateFrames(item.Frames, matrixWidth*matrixHeight); err != nil {
+		return fmt.Errorf("refusing to play malformed animation: %w", err)
+	}
 
-	if err := ActivateFxMode(deviceInfo); err != nil {
-		return fmt.Errorf("failed to activate fx mode: %w", err)
+	output, err := openPlayback(ctx, state)
+	if err != nil {
+		return err
 	}
+	defer output.Close()
+	return output.play(ctx, state, item, 0)
+}
 
-	fb := NewFramebuffer(20, 5)
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+// playback is where an animation's frames go: the device, prepared for
+// direct LED control, or a simulated one. A playlist opens it once for all
+// of its items.
+type playback struct {
+	deviceInfo    *DeviceInfo
+	sink          LedSink
+	conn          *DeviceConn
+	notifications <-chan map[string]string
+}
 
-	frameIndex := 0
+// openPlayback switches the device on and into fx mode and connects to it,
+// unless Simulate is set.
+func openPlayback(ctx context.Context, state *AnimationState) (*playback, error) {
+	output := &playback{deviceInfo: &DeviceInfo{Location: state.DeviceLocation}}
+	if Simulate {
+		output.sink = newSimulatedSink(state.DeviceLocation)
+		return output, nil
+	}
+
+	if err := SetPower(output.deviceInfo, true, "sudden", 0); err != nil {
+		return nil, fmt.Errorf("failed to power on device: %w", err)
+	}
+	if err := ActivateFxMode(output.deviceInfo); err != nil {
+		return nil, fmt.Errorf("failed to activate fx mode: %w", err)
+	}
+
+	output.conn = NewDeviceConn(output.deviceInfo)
+	output.notifications = output.conn.Notifications()
+	if connectErr := output.conn.Connect(ctx); connectErr != nil {
+		slog.Warn("Power state sync unavailable", "device", state.DeviceLocation, "error", connectErr)
+	}
+	output.sink = deviceSink{conn: output.conn}
+	return output, nil
+}
+
+// Close closes the device connection, if any.
+func (p *playback) Close() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+}
+
+// play plays the frames of item with the playback settings of state until
+// ctx is done, the item has been playing for playFor or, with a loop count,
+// the last pass ends. A zero playFor plays the item without a time limit.
+func (p *playback) play(ctx context.Context, state *AnimationState, item PlaylistItem, playFor time.Duration) error {
+	deviceInfo := p.deviceInfo
+	sink := p.sink
+	fb := NewMatrixFramebuffer()
+	state.frameCount.Store(int64(len(item.Frames)))
+	state.frameIndex.Store(0)
+
+	frameDuration := item.FrameDuration
+	if frameDuration <= 0 {
+		frameDuration = defaultFrameDuration
+	}
+	perFrame := len(item.FrameDurations) > 0 && len(item.FrameDurations) == len(item.Frames)
+	if perFrame {
+		frameDuration = slices.Min(item.FrameDurations)
+	}
+	// Transition frames are limited to what fits in the fastest frame rate
+	// allowed, so transitions never stretch the frame duration.
+	substeps := max(state.TransitionSteps, 0) + 1
+	substeps = min(substeps, max(int(frameDuration/fpsToInterval(animationMaxFPS)), 1))
+	frameDuration /= time.Duration(substeps)
+	start := time.Now()
+	throttle := newAdaptiveThrottle(frameDuration)
+	state.throttle.Store(throttle)
+	scheduler := newFrameScheduler(start, throttle.Interval())
+	meter := newFPSMeter(start)
+	state.meter.Store(meter)
+	timer := time.NewTimer(time.Until(scheduler.Next(start)))
+	defer timer.Stop()
+	clock := newPlayClock(playFor)
+	defer clock.Stop()
+	clock.Run(!state.Paused() && !state.PausedForPower.Load())
+
+	loopCount := state.LoopCount
+	if state.Preview {
+		loopCount = 1
+	}
+	cursor := &playbackCursor{mode: state.Mode, frameCount: len(item.Frames)}
+	step := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
-			if frameIndex >= len(state.Frames) {
-				frameIndex = 0
+		case <-clock.C():
+			return nil
+		case props, ok := <-p.notifications:
+			if !ok {
+				p.notifications = nil
+				continue
+			}
+			power, found := props["power"]
+			if !found {
+				continue
+			}
+			wasPaused := state.PausedForPower.Swap(power == "off")
+			if wasPaused && power == "on" {
+				if err := ActivateFxMode(deviceInfo); err != nil {
+					slog.Error("Error reactivating fx mode", "device", state.DeviceLocation, "error", err)
+				}
+			}
+			clock.Run(!state.Paused() && !state.PausedForPower.Load())
+			state.publishStatus()
+		case <-state.pauseChanged:
+			if state.Paused() {
+				timer.Stop()
+			} else {
+				scheduler = newFrameScheduler(time.Now(), throttle.Interval())
+				timer.Reset(0)
+			}
+			clock.Run(!state.Paused() && !state.PausedForPower.Load())
+			state.publishStatus()
+		case <-timer.C:
+			if state.Paused() {
+				continue
+			}
+			frameIndex := cursor.index
+			if perFrame {
+				hold := max(item.FrameDurations[frameIndex]/time.Duration(substeps), throttle.Interval())
+				timer.Reset(time.Until(scheduler.NextAfter(time.Now(), hold)))
+			} else {
+				timer.Reset(time.Until(scheduler.Next(time.Now())))
+			}
+			if state.PausedForPower.Load() {
+				continue
 			}
 
-			copy(fb.Pixels, state.Frames[frameIndex])
-			if err := UpdateLeds(deviceInfo, fb.Encode()); err != nil {
-				slog.Error("Error updating LEDs", "device", state.DeviceLocation, "error", err)
+			nextIndex, passDone := cursor.Peek()
+			if step == 0 || (passDone && loopCount > 0 && cursor.passes+1 >= loopCount) {
+				copy(fb.Pixels, item.Frames[frameIndex])
+			} else {
+				crossfade(fb.Pixels, item.Frames[frameIndex], item.Frames[nextIndex], float64(step)/float64(substeps))
+			}
+			wb := DeviceWhiteBalance(state.DeviceLocation)
+			fb.WhiteBalance = &wb
+			sendStart := time.Now()
+			err := sink.Update(ctx, fb)
+			now := time.Now()
+			throttle.Observe(now.Sub(sendStart), err)
+			if err != nil {
+				// A dropped frame is replaced by the next one; the throttle
+				// backs off if the device keeps failing.
+				slog.Debug("Error updating LEDs", "device", state.DeviceLocation, "error", err)
+			} else {
+				meter.Frame(now)
+			}
+			if interval := throttle.Interval(); !perFrame && interval != scheduler.interval {
+				scheduler = newFrameScheduler(sendStart, interval)
+				timer.Reset(time.Until(scheduler.Next(now)))
+			}
+			if step == 0 {
+				state.frameIndex.Store(int64(frameIndex))
+				state.publishStatus()
 			}
 
-			frameIndex++
+			if step++; step < substeps {
+				continue
+			}
+			step = 0
+			if cursor.Advance() && loopCount > 0 && cursor.passes >= loopCount {
+				return nil
+			}
 		}
 	}
 }
 
-func StartDeviceAnimation(deviceLocation string, frames [][]Color) {
-	StopDeviceAnimation(deviceLocation)
+// playClock measures how long an item has been playing, leaving out the time
+// it spent paused or with the device off, and fires once limit is used up.
+// A clock with a zero limit never fires.
+type playClock struct {
+	limit time.Duration
+	used  time.Duration
+	since time.Time // when the clock last started; zero while it is stopped
+	timer *time.Timer
+}
+
+func newPlayClock(limit time.Duration) *playClock {
+	clock := &playClock{limit: limit}
+	if limit > 0 {
+		clock.timer = time.NewTimer(limit)
+		clock.timer.Stop()
+	}
+	return clock
+}
+
+// C fires once the clock has run for its limit.
+func (c *playClock) C() <-chan time.Time {
+	if c.timer == nil {
+		return nil
+	}
+	return c.timer.C
+}
 
-	ctx, cancelFunc := context.WithCancel(context.Background())
-	done := make(chan struct{})
+// Run starts or stops the clock; it keeps the time already used.
+func (c *playClock) Run(running bool) {
+	if c.timer == nil || running == !c.since.IsZero() {
+		return
+	}
+	now := time.Now()
+	if running {
+		c.since = now
+		c.timer.Reset(c.limit - c.used)
+		return
+	}
+	c.timer.Stop()
+	c.used += now.Sub(c.since)
+	c.since = time.Time{}
+}
+
+// Stop releases the clock's timer.
+func (c *playClock) Stop() {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+}
+
+// crossfade fills dst with the pixels of from blended towards to by t in
+// [0, 1].
+func crossfade(dst, from, to []Color, t float64) {
+	for i := range dst {
+		dst[i] = lerpColor(from[i], to[i], t)
+	}
+}
+
+// playbackCursor walks the frame indices of an animation in playback order.
+type playbackCursor struct {
+	mode       PlaybackMode
+	frameCount int
+	index      int
+	reverse    bool
+	passes     int
+}
+
+// Advance moves to the next frame and reports whether a pass over the frames
+// was just completed. A forward pass ends after the last frame; a ping-pong
+// pass ends after playing back to the first frame, which is not repeated at
+// the start of the next pass.
+func (c *playbackCursor) Advance() bool {
+	if c.mode != PlaybackPingPong || c.frameCount < 2 {
+		c.index++
+		if c.index < c.frameCount {
+			return false
+		}
+		c.index = 0
+		c.passes++
+		return true
+	}
+
+	if !c.reverse {
+		c.index++
+		c.reverse = c.index == c.frameCount-1
+		return false
+	}
+	if c.index > 0 {
+		c.index--
+		return false
+	}
+	c.index = 1
+	c.reverse = c.index == c.frameCount-1
+	c.passes++
+	return true
+}
+
+// Peek returns the frame Advance would move to and whether doing so would
+// complete a pass, without moving.
+func (c *playbackCursor) Peek() (int, bool) {
+	next := *c
+	passDone := next.Advance()
+	return next.index, passDone
+}
+
+// StartDeviceAnimation starts playing state on its device, replacing any
+// animation already running there.
+func StartDeviceAnimation(state *AnimationState) {
+	runDeviceAnimation(state, func(ctx context.Context) error {
+		return PlayAnimation(ctx, state)
+	})
+}
+
+// StartDevicePreview plays state once as a preview. A preview only replaces
+// another preview: it fails with ErrAnimationRunning rather than stop the
+// animation running on the device, which could not be resumed afterwards.
+func StartDevicePreview(state *AnimationState) error {
+	state.Preview = true
+	return startDeviceAnimation(state, true, func(ctx context.Context) error {
+		return PlayAnimation(ctx, state)
+	})
+}
+
+// PlaylistItem is a single animation of a playlist with its own frame timing.
+type PlaylistItem struct {
+	Frames         [][]Color
+	FrameDuration  time.Duration
+	FrameDurations []time.Duration
+}
+
+// StartDevicePlaylist plays each animation of the playlist for itemDuration,
+// looping back to the first one after the last. Time spent paused does not
+// count towards itemDuration. Like a single animation it
+// replaces whatever is running on the device and is stopped with
+// StopDeviceAnimation.
+func StartDevicePlaylist(deviceLocation string, playlist []PlaylistItem, itemDuration time.Duration) {
 	state := &AnimationState{
 		DeviceLocation: deviceLocation,
-		Frames:         frames,
-		StopFunc: func() {
-			cancelFunc()
-			<-done
-		},
+		Frames:         playlist[0].Frames,
+		FrameDuration:  playlist[0].FrameDuration,
+		FrameDurations: playlist[0].FrameDurations,
+	}
+	runDeviceAnimation(state, func(ctx context.Context) error {
+		return PlayPlaylist(ctx, state, playlist, itemDuration)
+	})
+}
+
+// PlayPlaylist plays the playlist items in order until ctx is cancelled.
+// The device is prepared once for the whole playlist; state keeps the
+// first item's frames, and its status reports the item playing.
+func PlayPlaylist(
+	ctx context.Context,
+	state *AnimationState,
+	playlist []PlaylistItem,
+	itemDuration time.Duration,
+) error {
+	for i, item := range playlist {
+		if err := validateFrames(item.Frames, matrixWidth*matrixHeight); err != nil {
+			return fmt.Errorf("refusing to play malformed playlist item %d: %w", i, err)
+		}
 	}
 
-	animationsMu.Lock()
-	runningAnimations[deviceLocation] = state
-	animationsMu.Unlock()
+	output, err := openPlayback(ctx, state)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	for item := 0; ctx.Err() == nil; item = (item + 1) % len(playlist) {
+		if err := output.play(ctx, state, playlist[item], itemDuration); err != nil {
+			return fmt.Errorf("failed to play playlist item %d: %w", item, err)
+		}
+	}
+	return nil
+}
+
+// runDeviceAnimation registers state in the running animations registry,
+// replacing any animation already running on the device, and runs play in
+// the background until it returns or the animation is stopped.
+func runDeviceAnimation(state *AnimationState, play func(ctx context.Context) error) {
+	_ = startDeviceAnimation(state, false, play)
+}
+
+// startDeviceAnimation is runDeviceAnimation that, with onlyPreviews set,
+// fails with ErrAnimationRunning instead of replacing an animation that is
+// not a preview. The device is claimed under animationsMu only once it is
+// free, so an animation started concurrently is never replaced unchecked.
+func startDeviceAnimation(state *AnimationState, onlyPreviews bool, play func(ctx context.Context) error) error {
+	deviceLocation := state.DeviceLocation
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	state.pauseChanged = make(chan struct{}, 1)
+	state.frameCount.Store(int64(len(state.Frames)))
+	state.StopFunc = func() {
+		cancelFunc()
+		<-done
+	}
+
+	for {
+		animationsMu.Lock()
+		current, exists := runningAnimations[deviceLocation]
+		if !exists {
+			runningAnimations[deviceLocation] = state
+			animationsMu.Unlock()
+			break
+		}
+		animationsMu.Unlock()
+		if onlyPreviews && !current.Preview {
+			cancelFunc()
+			return ErrAnimationRunning
+		}
+		// StopFunc waits for the animation to unregister itself, which
+		// takes animationsMu, so the lock must not be held here.
+		current.StopFunc()
+	}
+	state.publishStatus()
 
 	go func() {
 		defer func() {
 			animationsMu.Lock()
-			delete(runningAnimations, deviceLocation)
+			// A newer animation may have taken the device over already;
+			// its entry must stay.
+			current := runningAnimations[deviceLocation] == state
+			if current {
+				delete(runningAnimations, deviceLocation)
+			}
 			animationsMu.Unlock()
+			if current && !state.Preview {
+				animationStatuses.Publish(deviceLocation, AnimationStatus{})
+			}
 			close(done)
 		}()
 
-		if err := PlayAnimation(ctx, state); err != nil {
+		if err := play(ctx); err != nil {
 			slog.Error("Animation error", "device", deviceLocation, "error", err)
 		}
+		if state.ClearOnStop && ctx.Err() != nil {
+			if err := clearMatrix(deviceLocation); err != nil {
+				slog.Error("Error clearing LEDs", "device", deviceLocation, "error", err)
+			}
+		}
 	}()
+	return nil
+}
+
+// clearMatrix switches every LED of the device off.
+func clearMatrix(deviceLocation string) error {
+	if Simulate {
+		return newSimulatedSink(deviceLocation).Update(context.Background(), NewMatrixFramebuffer())
+	}
+	return UpdateFramebuffer(&DeviceInfo{Location: deviceLocation}, NewMatrixFramebuffer())
+}
+
+// publishStatus sends the current playback status to status subscribers.
+// Previews are not reported.
+func (s *AnimationState) publishStatus() {
+	if s.Preview {
+		return
+	}
+	animationStatuses.Publish(s.DeviceLocation, s.status())
+}
+
+func (s *AnimationState) status() AnimationStatus {
+	return AnimationStatus{
+		Running:        true,
+		FrameIndex:     int(s.frameIndex.Load()),
+		FrameCount:     int(s.frameCount.Load()),
+		Paused:         s.Paused(),
+		PausedForPower: s.PausedForPower.Load(),
+	}
+}
+
+// DeviceAnimation returns the animation running on the device, if any.
+// Previews are not reported.
+func DeviceAnimation(deviceLocation string) (*AnimationState, bool) {
+	animationsMu.RLock()
+	defer animationsMu.RUnlock()
+	state, exists := runningAnimations[deviceLocation]
+	if !exists || state.Preview {
+		return nil, false
+	}
+	return state, true
+}
+
+// ListRunningAnimations returns the locations of all devices with an
+// animation playing, including previews, in sorted order.
+func ListRunningAnimations() []string {
+	states := runningAnimationStates()
+	locations := make([]string, len(states))
+	for i, state := range states {
+		locations[i] = state.DeviceLocation
+	}
+	return locations
+}
+
+// runningAnimationStates returns every running animation, including
+// previews, ordered by device location.
+func runningAnimationStates() []*AnimationState {
+	animationsMu.RLock()
+	states := make([]*AnimationState, 0, len(runningAnimations))
+	for _, state := range runningAnimations {
+		states = append(states, state)
+	}
+	animationsMu.RUnlock()
+
+	slices.SortFunc(states, func(a, b *AnimationState) int {
+		return strings.Compare(a.DeviceLocation, b.DeviceLocation)
+	})
+	return states
 }
 
 func StopDeviceAnimation(deviceLocation string) {
@@ -103,3 +652,36 @@ func StopDeviceAnimation(deviceLocation string) {
 		state.StopFunc()
 	}
 }
+
+// StopAllAnimations stops every running animation, including previews, and
+// waits for each to finish, leaving the registry empty. It is called on
+// shutdown so no animation keeps sending frames.
+func StopAllAnimations() {
+	// StopFunc waits for the animation to unregister itself, which takes
+	// animationsMu, so the lock must not be held here.
+	for _, state := range runningAnimationStates() {
+		state.StopFunc()
+	}
+}
+
+// PauseDeviceAnimation freezes the animation running on the device on its
+// current frame until ResumeDeviceAnimation is called.
+func PauseDeviceAnimation(deviceLocation string) error {
+	state, running := DeviceAnimation(deviceLocation)
+	if !running {
+		return ErrNoAnimation
+	}
+	state.setPaused(true)
+	return nil
+}
+
+// ResumeDeviceAnimation continues a paused animation with the frame after the
+// one it was paused on.
+func ResumeDeviceAnimation(deviceLocation string) error {
+	state, running := DeviceAnimation(deviceLocation)
+	if !running {
+		return ErrNoAnimation
+	}
+	state.setPaused(false)
+	return nil
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// solidFrame returns a matrix frame filled with color.
+func solidFrame(color Color) []Color {
+	fb := NewMatrixFramebuffer()
+	fb.Clear(color)
+	return fb.Pixels
+}
+
+// encodedFrame returns the update_leds payload a device receives for frame.
+func encodedFrame(deviceLocation string, frame []Color) string {
+	fb := NewMatrixFramebuffer()
+	copy(fb.Pixels, frame)
+	wb := DeviceWhiteBalance(deviceLocation)
+	fb.WhiteBalance = &wb
+	return fb.Encode()
+}
+
+// countCommands returns how many of commands call method.
+func countCommands(commands []CommandRequest, method string) int {
+	count := 0
+	for _, cmd := range commands {
+		if cmd.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPlayPlaylist(t *testing.T) {
+	red := solidFrame(Color{R: 255})
+	blue := solidFrame(Color{B: 255})
+
+	tests := []struct {
+		name     string
+		playlist [][][]Color
+		// wantRuns is the order in which frames are first shown; repeats of
+		// a frame in a row count once.
+		wantRuns [][]Color
+	}{
+		{
+			name:     "single item",
+			playlist: [][][]Color{{red}},
+			wantRuns: [][]Color{red},
+		},
+		{
+			name:     "advances and wraps around",
+			playlist: [][][]Color{{red}, {blue}},
+			wantRuns: [][]Color{red, blue, red},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, replyOK)
+			playlist := make([]PlaylistItem, len(tt.playlist))
+			for i, frames := range tt.playlist {
+				playlist[i] = PlaylistItem{Frames: frames, FrameDuration: 100 * time.Millisecond}
+			}
+			state := &AnimationState{DeviceLocation: device.Location()}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 550*time.Millisecond)
+			defer cancel()
+			if err := PlayPlaylist(ctx, state, playlist, 200*time.Millisecond); err != nil {
+				t.Fatalf("PlayPlaylist() error = %v", err)
+			}
+
+			commands := device.Commands()
+			var runs []string
+			for _, cmd := range commands {
+				if cmd.Method != "update_leds" {
+					continue
+				}
+				payload, _ := cmd.Params[0].(string)
+				if len(runs) == 0 || runs[len(runs)-1] != payload {
+					runs = append(runs, payload)
+				}
+			}
+			want := make([]string, len(tt.wantRuns))
+			for i, frame := range tt.wantRuns {
+				want[i] = encodedFrame(device.Location(), frame)
+			}
+			if len(runs) < len(want) || !slices.Equal(runs[:len(want)], want) {
+				t.Errorf("shown %d runs of frames, want them to start with %d in playlist order", len(runs), len(want))
+			}
+
+			for _, method := range []string{"set_power", "activate_fx_mode"} {
+				if got := countCommands(commands, method); got != 1 {
+					t.Errorf("%s sent %d times, want once per playlist", method, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayPlaylistPause(t *testing.T) {
+	device := newFakeDevice(t, replyOK)
+	red := encodedFrame(device.Location(), solidFrame(Color{R: 255}))
+	blue := encodedFrame(device.Location(), solidFrame(Color{B: 255}))
+	playlist := []PlaylistItem{
+		{Frames: [][]Color{solidFrame(Color{R: 255})}, FrameDuration: 50 * time.Millisecond},
+		{Frames: [][]Color{solidFrame(Color{B: 255})}, FrameDuration: 50 * time.Millisecond},
+	}
+	state := &AnimationState{DeviceLocation: device.Location(), pauseChanged: make(chan struct{}, 1)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- PlayPlaylist(ctx, state, playlist, 300*time.Millisecond) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// frames returns the update_leds payloads sent from the from-th one on.
+	frames := func(from int) []string {
+		var payloads []string
+		for _, cmd := range device.Commands() {
+			if cmd.Method == "update_leds" {
+				payload, _ := cmd.Params[0].(string)
+				payloads = append(payloads, payload)
+			}
+		}
+		return payloads[min(from, len(payloads)):]
+	}
+	waitFor(t, "the first item to play", func() bool { return len(frames(0)) > 0 })
+	time.Sleep(100 * time.Millisecond)
+	state.setPaused(true)
+	time.Sleep(350 * time.Millisecond)
+	sent := len(frames(0))
+	resumed := time.Now()
+	state.setPaused(false)
+
+	waitFor(t, "frames after resuming", func() bool { return len(frames(sent)) > 0 })
+	if got := frames(sent)[0]; got != red {
+		t.Errorf("first frame after resuming is not from the first item, the pause moved the playlist on")
+	}
+	waitFor(t, "the second item to play", func() bool { return slices.Contains(frames(sent), blue) })
+	if played := time.Since(resumed); played < 150*time.Millisecond {
+		t.Errorf("first item played %v after resuming, want the rest of its 300ms", played)
+	}
+}
+
+func TestValidateFrames(t *testing.T) {
+	full := solidFrame(Color{G: 255})
+	tooMany := make([][]Color, maxAnimationFrames+1)
+	for i := range tooMany {
+		tooMany[i] = full
+	}
+
+	tests := []struct {
+		name    string
+		frames  [][]Color
+		wantErr bool
+	}{
+		{name: "consistent frames", frames: [][]Color{full, full}},
+		{name: "no frames", frames: nil, wantErr: true},
+		{name: "short frame", frames: [][]Color{full, full[:10]}, wantErr: true},
+		{name: "long frame", frames: [][]Color{append(slices.Clone(full), Color{})}, wantErr: true},
+		{name: "too many frames", frames: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFrames(tt.frames, matrixWidth*matrixHeight)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFrames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlayAnimationRefusesMalformedFrames(t *testing.T) {
+	device := newFakeDevice(t, replyOK)
+	state := &AnimationState{
+		DeviceLocation: device.Location(),
+		Frames:         [][]Color{solidFrame(Color{R: 255}), make([]Color, 3)},
+	}
+
+	if err := PlayAnimation(context.Background(), state); err == nil {
+		t.Fatal("PlayAnimation() succeeded with a short frame")
+	}
+	if sent := len(device.Commands()); sent != 0 {
+		t.Errorf("device received %d commands, want none", sent)
+	}
+}
+
+// runningAnimation returns the state registered for the device, including
+// previews.
+func runningAnimation(deviceLocation string) *AnimationState {
+	animationsMu.RLock()
+	defer animationsMu.RUnlock()
+	return runningAnimations[deviceLocation]
+}
+
+func TestStartDevicePreview(t *testing.T) {
+	tests := []struct {
+		name    string
+		running string
+		wantErr error
+	}{
+		{name: "idle device"},
+		{name: "replaces a preview", running: "preview"},
+		{name: "keeps a running animation", running: "animation", wantErr: ErrAnimationRunning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, replyOK)
+			t.Cleanup(func() { StopDeviceAnimation(device.Location()) })
+			frames := [][]Color{solidFrame(Color{R: 255}), solidFrame(Color{B: 255})}
+
+			var running *AnimationState
+			switch tt.running {
+			case "preview":
+				running = &AnimationState{DeviceLocation: device.Location(), Frames: frames}
+				if err := StartDevicePreview(running); err != nil {
+					t.Fatalf("StartDevicePreview() error = %v", err)
+				}
+			case "animation":
+				running = &AnimationState{DeviceLocation: device.Location(), Frames: frames}
+				StartDeviceAnimation(running)
+			}
+
+			preview := &AnimationState{DeviceLocation: device.Location(), Frames: frames}
+			err := StartDevicePreview(preview)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartDevicePreview() error = %v, want %v", err, tt.wantErr)
+			}
+
+			want := preview
+			if tt.wantErr != nil {
+				want = running
+			}
+			if got := runningAnimation(device.Location()); got != want {
+				t.Errorf("device runs %p, want %p", got, want)
+			}
+			if current, ok := DeviceAnimation(device.Location()); tt.wantErr == nil && ok {
+				t.Errorf("DeviceAnimation() reported preview %p as the running animation", current)
+			}
+		})
+	}
+}
+
+func TestStartDevicePreviewRacingAnimation(t *testing.T) {
+	device := newFakeDevice(t, replyOK)
+	t.Cleanup(func() { StopDeviceAnimation(device.Location()) })
+	frames := [][]Color{solidFrame(Color{R: 255})}
+
+	for range 100 {
+		animation := &AnimationState{DeviceLocation: device.Location(), Frames: frames}
+		preview := &AnimationState{DeviceLocation: device.Location(), Frames: frames}
+		// Both starts queue up on the registry lock and race once it is
+		// released.
+		var wg sync.WaitGroup
+		animationsMu.Lock()
+		wg.Go(func() { StartDeviceAnimation(animation) })
+		wg.Go(func() { _ = StartDevicePreview(preview) })
+		time.Sleep(time.Millisecond)
+		animationsMu.Unlock()
+		wg.Wait()
+
+		if got := runningAnimation(device.Location()); got != animation {
+			t.Fatalf("device runs %p, want the animation %p and not the preview %p", got, animation, preview)
+		}
+		StopDeviceAnimation(device.Location())
+	}
+}
+
+func TestPlayAnimationPausesWhilePoweredOff(t *testing.T) {
+	device := newFakeDevice(t, replyOK)
+	state := &AnimationState{
+		DeviceLocation: device.Location(),
+		Frames:         [][]Color{solidFrame(Color{R: 255}), solidFrame(Color{B: 255})},
+		FrameDuration:  100 * time.Millisecond,
+	}
+	StartDeviceAnimation(state)
+	t.Cleanup(func() { StopDeviceAnimation(device.Location()) })
+
+	frames := func() int { return countCommands(device.Commands(), "update_leds") }
+	waitFor(t, "the first frames", func() bool { return frames() >= 2 })
+
+	device.Notify(map[string]string{"power": "off"})
+	waitFor(t, "playback to pause", state.PausedForPower.Load)
+	sent := frames()
+	time.Sleep(300 * time.Millisecond)
+	// A frame may have been on its way when the notification arrived.
+	if extra := frames() - sent; extra > 1 {
+		t.Errorf("%d frames sent while the device was off", extra)
+	}
+
+	device.Notify(map[string]string{"power": "on"})
+	waitFor(t, "playback to resume", func() bool { return !state.PausedForPower.Load() })
+	sent = frames()
+	waitFor(t, "frames after power on", func() bool { return frames() > sent+1 })
+	if got := countCommands(device.Commands(), "activate_fx_mode"); got != 2 {
+		t.Errorf("activate_fx_mode sent %d times, want again after power on", got)
+	}
+}
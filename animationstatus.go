@@ -0,0 +1,70 @@
+package main
+
+import "sync"
+
+// AnimationStatus is a snapshot of animation playback on a device.
+type AnimationStatus struct {
+	Running        bool `json:"running"`
+	FrameIndex     int  `json:"frame_index"`
+	FrameCount     int  `json:"frame_count"`
+	Paused         bool `json:"paused"`
+	PausedForPower bool `json:"paused_for_power"`
+}
+
+// animationStatusHub fans out playback updates to subscribers per device.
+// Subscribers only ever see the latest status: an update replaces one the
+// subscriber has not read yet, so a slow client never stalls playback.
+type animationStatusHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan AnimationStatus]struct{}
+}
+
+var animationStatuses = &animationStatusHub{
+	subscribers: make(map[string]map[chan AnimationStatus]struct{}),
+}
+
+// Subscribe returns a channel receiving status updates for the device and a
+// function that ends the subscription.
+func (h *animationStatusHub) Subscribe(deviceLocation string) (<-chan AnimationStatus, func()) {
+	updates := make(chan AnimationStatus, 1)
+
+	h.mu.Lock()
+	if h.subscribers[deviceLocation] == nil {
+		h.subscribers[deviceLocation] = make(map[chan AnimationStatus]struct{})
+	}
+	h.subscribers[deviceLocation][updates] = struct{}{}
+	h.mu.Unlock()
+
+	return updates, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[deviceLocation], updates)
+		if len(h.subscribers[deviceLocation]) == 0 {
+			delete(h.subscribers, deviceLocation)
+		}
+	}
+}
+
+// Publish delivers status to every subscriber of the device.
+func (h *animationStatusHub) Publish(deviceLocation string, status AnimationStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for updates := range h.subscribers[deviceLocation] {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- status
+	}
+}
+
+// CurrentAnimationStatus returns the status of the animation running on the
+// device. Previews are not reported.
+func CurrentAnimationStatus(deviceLocation string) AnimationStatus {
+	state, running := DeviceAnimation(deviceLocation)
+	if !running {
+		return AnimationStatus{}
+	}
+	return state.status()
+}
@@ -27,36 +27,168 @@ func trimTrailingSlashes(u *url.URL) {
 
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
+	// ApplyDeviceState invokes applyDeviceState operation.
+	//
+	// Applies power, brightness and color to the device in one request. All given settings are sent over
+	// a single connection; power on is applied first and power off last.
+	//
+	// POST /api/device/state
+	ApplyDeviceState(ctx context.Context, request *ApplyDeviceStateRequest) (ApplyDeviceStateRes, error)
 	// DeleteAnimation invokes deleteAnimation operation.
 	//
 	// Permanently removes a saved animation from the database.
 	//
 	// DELETE /api/animation/{id}
 	DeleteAnimation(ctx context.Context, params DeleteAnimationParams) (DeleteAnimationRes, error)
+	// DeleteAnimations invokes deleteAnimations operation.
+	//
+	// Permanently removes the animations with the given IDs. IDs that do not exist are ignored.
+	//
+	// POST /api/animation/delete
+	DeleteAnimations(ctx context.Context, request *DeleteAnimationsRequest) (DeleteAnimationsRes, error)
+	// DeleteDeviceAnimations invokes deleteDeviceAnimations operation.
+	//
+	// Permanently removes every animation saved for the specified device, including their undo history.
+	//
+	// DELETE /api/animation/list/{device_id}
+	DeleteDeviceAnimations(ctx context.Context, params DeleteDeviceAnimationsParams) (DeleteDeviceAnimationsRes, error)
+	// DuplicateAnimation invokes duplicateAnimation operation.
+	//
+	// Saves a copy of the animation with a new ID, including its frames, timing and tags. The copy
+	// starts without undo history.
+	//
+	// POST /api/animation/{id}/duplicate
+	DuplicateAnimation(ctx context.Context, request OptDuplicateAnimationRequest, params DuplicateAnimationParams) (DuplicateAnimationRes, error)
+	// ExportAnimationGif invokes exportAnimationGif operation.
+	//
+	// Renders the animation as a looping GIF with every matrix pixel scaled up to a block of pixels.
+	// Frames keep their per-frame durations when the animation has them, otherwise they play at the
+	// requested fps or the animation's frame duration.
+	//
+	// GET /api/animation/{id}/gif
+	ExportAnimationGif(ctx context.Context, params ExportAnimationGifParams) (ExportAnimationGifRes, error)
 	// GetAnimation invokes getAnimation operation.
 	//
 	// Retrieves a saved animation by its ID.
 	//
 	// GET /api/animation/{id}
 	GetAnimation(ctx context.Context, params GetAnimationParams) (GetAnimationRes, error)
+	// GetAnimationThumbnail invokes getAnimationThumbnail operation.
+	//
+	// Renders the first frame of the animation as a PNG with every matrix pixel scaled up to a block of
+	// pixels.
+	//
+	// GET /api/animation/{id}/thumbnail
+	GetAnimationThumbnail(ctx context.Context, params GetAnimationThumbnailParams) (GetAnimationThumbnailRes, error)
+	// GetDeviceStatus invokes getDeviceStatus operation.
+	//
+	// Queries the device for its current power, brightness and color mode, together with the color modes
+	// and color temperature range it supports.
+	//
+	// GET /api/device/status
+	GetDeviceStatus(ctx context.Context, params GetDeviceStatusParams) (GetDeviceStatusRes, error)
 	// GetDevices invokes getDevices operation.
 	//
-	// Performs live SSDP discovery and returns currently available devices on the local network.
+	// Performs live SSDP discovery and returns the devices that answered, followed by devices seen
+	// within the last day that did not answer, marked offline.
 	//
 	// GET /api/devices
 	GetDevices(ctx context.Context) (GetDevicesRes, error)
+	// GetWhiteBalance invokes getWhiteBalance operation.
+	//
+	// Returns the per-channel gains applied to every frame sent to the device. Devices without
+	// calibration report gains of 1.
+	//
+	// GET /api/device/white-balance
+	GetWhiteBalance(ctx context.Context, params GetWhiteBalanceParams) (GetWhiteBalanceRes, error)
+	// ImportGifAnimation invokes importGifAnimation operation.
+	//
+	// Decodes the uploaded GIF, fits every frame to the 20x5 matrix by cropping to its aspect ratio and
+	// nearest-neighbour scaling, and saves the result like saveAnimation. Frame delays from the GIF
+	// become per-frame durations.
+	//
+	// POST /api/animation/import/gif
+	ImportGifAnimation(ctx context.Context, request ImportGifAnimationReq, params ImportGifAnimationParams) (ImportGifAnimationRes, error)
+	// ImportImageAnimation invokes importImageAnimation operation.
+	//
+	// Decodes the uploaded image, fits it to the 20x5 matrix by cropping to its aspect ratio and
+	// averaging, and saves it as a single-frame animation. Transparent areas become black.
+	//
+	// POST /api/animation/import/image
+	ImportImageAnimation(ctx context.Context, request ImportImageAnimationReq, params ImportImageAnimationParams) (ImportImageAnimationRes, error)
 	// ListAnimations invokes listAnimations operation.
 	//
-	// Returns all saved animations for the specified device, ordered by most recently updated.
+	// Returns the saved animations for the specified device, by default ordered by most recently updated.
+	//  Results can be filtered by name, tag and creation time and sorted by name or date.
 	//
 	// GET /api/animation/list/{device_id}
 	ListAnimations(ctx context.Context, params ListAnimationsParams) (ListAnimationsRes, error)
+	// ListRunningAnimations invokes listRunningAnimations operation.
+	//
+	// Reports every device with an animation playing, including previews, with its playback position.
+	// Useful to find stale animations still driving a device.
+	//
+	// GET /api/animation/running
+	ListRunningAnimations(ctx context.Context) (*RunningAnimationsResponse, error)
+	// PauseAnimation invokes pauseAnimation operation.
+	//
+	// Freezes the animation running on the specified device on its current frame, keeping its position
+	// so it can be resumed.
+	//
+	// POST /api/animation/pause
+	PauseAnimation(ctx context.Context, request *PauseAnimationRequest) (PauseAnimationRes, error)
+	// PingDevice invokes pingDevice operation.
+	//
+	// Reports whether the device accepts connections. Results are cached for a short time and refreshed
+	// in the background, so frequent polling does not dial the device on every request.
+	//
+	// GET /api/device/ping
+	PingDevice(ctx context.Context, params PingDeviceParams) (*PingDeviceResponse, error)
+	// RedoAnimation invokes redoAnimation operation.
+	//
+	// Reapplies the last update reverted by undo. Redo history is discarded when the animation is
+	// updated.
+	//
+	// POST /api/animation/{id}/redo
+	RedoAnimation(ctx context.Context, params RedoAnimationParams) (RedoAnimationRes, error)
+	// ResumeAnimation invokes resumeAnimation operation.
+	//
+	// Continues a paused animation on the specified device with the frame after the one it was paused on.
+	//
+	// POST /api/animation/resume
+	ResumeAnimation(ctx context.Context, request *ResumeAnimationRequest) (ResumeAnimationRes, error)
 	// SaveAnimation invokes saveAnimation operation.
 	//
 	// Saves the current animation frames to the database with a name. Stored per device.
 	//
 	// POST /api/animation/save
 	SaveAnimation(ctx context.Context, request *SaveAnimationRequest) (SaveAnimationRes, error)
+	// SetDeviceBrightness invokes setDeviceBrightness operation.
+	//
+	// Sets the device brightness in percent.
+	//
+	// POST /api/device/brightness
+	SetDeviceBrightness(ctx context.Context, request *SetDeviceBrightnessRequest) (SetDeviceBrightnessRes, error)
+	// SetDeviceName invokes setDeviceName operation.
+	//
+	// Sets the device name and reads it back to confirm the device stored it, since some firmware
+	// acknowledges the change without applying it.
+	//
+	// POST /api/device/name
+	SetDeviceName(ctx context.Context, request *SetDeviceNameRequest) (SetDeviceNameRes, error)
+	// SetDevicePower invokes setDevicePower operation.
+	//
+	// Sets the device power state. Unlike toggling, the result does not depend on the current state.
+	//
+	// POST /api/device/power
+	SetDevicePower(ctx context.Context, request *SetDevicePowerRequest) (SetDevicePowerRes, error)
+	// SetWhiteBalance invokes setWhiteBalance operation.
+	//
+	// Persists per-channel gains that correct the device's color cast. The gains apply to all subsequent
+	// frames, including those of a running animation.
+	//
+	// PUT /api/device/white-balance
+	SetWhiteBalance(ctx context.Context, request *SetWhiteBalanceRequest) (SetWhiteBalanceRes, error)
 	// StartAnimation invokes startAnimation operation.
 	//
 	// Starts playing an animation loop on the specified device. Only one animation can run per device at
@@ -64,12 +196,29 @@ type Invoker interface {
 	//
 	// POST /api/animation/start
 	StartAnimation(ctx context.Context, request *StartAnimationRequest) (StartAnimationRes, error)
+	// StartPlaylist invokes startPlaylist operation.
+	//
+	// Plays saved animations one after another on the specified device, switching to the next one after
+	// the per-item duration and looping back to the first after the last. The animations are given
+	// either as a list of IDs or as a tag, which selects all of a device's animations with that tag in
+	// the order they were created. Replaces any animation currently running on the device and is stopped
+	// like a single animation.
+	//
+	// POST /api/animation/playlist
+	StartPlaylist(ctx context.Context, request *StartPlaylistRequest) (StartPlaylistRes, error)
 	// StopAnimation invokes stopAnimation operation.
 	//
 	// Stops the currently running animation on the specified device. No-op if no animation is running.
 	//
 	// POST /api/animation/stop
 	StopAnimation(ctx context.Context, request *StopAnimationRequest) (StopAnimationRes, error)
+	// UndoAnimation invokes undoAnimation operation.
+	//
+	// Restores the animation to its state before the last update. Up to 20 previous states are kept per
+	// animation.
+	//
+	// POST /api/animation/{id}/undo
+	UndoAnimation(ctx context.Context, params UndoAnimationParams) (UndoAnimationRes, error)
 	// UpdateAnimation invokes updateAnimation operation.
 	//
 	// Overwrites an existing animation's name and frames.
@@ -121,21 +270,22 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 	return u
 }
 
-// DeleteAnimation invokes deleteAnimation operation.
+// ApplyDeviceState invokes applyDeviceState operation.
 //
-// Permanently removes a saved animation from the database.
+// Applies power, brightness and color to the device in one request. All given settings are sent over
+// a single connection; power on is applied first and power off last.
 //
-// DELETE /api/animation/{id}
-func (c *Client) DeleteAnimation(ctx context.Context, params DeleteAnimationParams) (DeleteAnimationRes, error) {
-	res, err := c.sendDeleteAnimation(ctx, params)
+// POST /api/device/state
+func (c *Client) ApplyDeviceState(ctx context.Context, request *ApplyDeviceStateRequest) (ApplyDeviceStateRes, error) {
+	res, err := c.sendApplyDeviceState(ctx, request)
 	return res, err
 }
 
-func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimationParams) (res DeleteAnimationRes, err error) {
+func (c *Client) sendApplyDeviceState(ctx context.Context, request *ApplyDeviceStateRequest) (res ApplyDeviceStateRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("deleteAnimation"),
-		semconv.HTTPRequestMethodKey.String("DELETE"),
-		semconv.URLTemplateKey.String("/api/animation/{id}"),
+		otelogen.OperationID("applyDeviceState"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/device/state"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
 
@@ -151,7 +301,7 @@ func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimation
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, DeleteAnimationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, ApplyDeviceStateOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -168,33 +318,18 @@ func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimation
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [2]string
-	pathParts[0] = "/api/animation/"
-	{
-		// Encode "id" parameter.
-		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "id",
-			Style:   uri.PathStyleSimple,
-			Explode: false,
-		})
-		if err := func() error {
-			return e.EncodeValue(conv.StringToString(params.ID))
-		}(); err != nil {
-			return res, errors.Wrap(err, "encode path")
-		}
-		encoded, err := e.Result()
-		if err != nil {
-			return res, errors.Wrap(err, "encode path")
-		}
-		pathParts[1] = encoded
-	}
+	var pathParts [1]string
+	pathParts[0] = "/api/device/state"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "DELETE", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeApplyDeviceStateRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
 
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
@@ -204,7 +339,7 @@ func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimation
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeDeleteAnimationResponse(resp)
+	result, err := decodeApplyDeviceStateResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -212,20 +347,20 @@ func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimation
 	return result, nil
 }
 
-// GetAnimation invokes getAnimation operation.
+// DeleteAnimation invokes deleteAnimation operation.
 //
-// Retrieves a saved animation by its ID.
+// Permanently removes a saved animation from the database.
 //
-// GET /api/animation/{id}
-func (c *Client) GetAnimation(ctx context.Context, params GetAnimationParams) (GetAnimationRes, error) {
-	res, err := c.sendGetAnimation(ctx, params)
+// DELETE /api/animation/{id}
+func (c *Client) DeleteAnimation(ctx context.Context, params DeleteAnimationParams) (DeleteAnimationRes, error) {
+	res, err := c.sendDeleteAnimation(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams) (res GetAnimationRes, err error) {
+func (c *Client) sendDeleteAnimation(ctx context.Context, params DeleteAnimationParams) (res DeleteAnimationRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getAnimation"),
-		semconv.HTTPRequestMethodKey.String("GET"),
+		otelogen.OperationID("deleteAnimation"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
 		semconv.URLTemplateKey.String("/api/animation/{id}"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
@@ -242,7 +377,7 @@ func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, GetAnimationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DeleteAnimationOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -282,7 +417,7 @@ func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "DELETE", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
@@ -295,7 +430,7 @@ func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeGetAnimationResponse(resp)
+	result, err := decodeDeleteAnimationResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -303,21 +438,21 @@ func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams
 	return result, nil
 }
 
-// GetDevices invokes getDevices operation.
+// DeleteAnimations invokes deleteAnimations operation.
 //
-// Performs live SSDP discovery and returns currently available devices on the local network.
+// Permanently removes the animations with the given IDs. IDs that do not exist are ignored.
 //
-// GET /api/devices
-func (c *Client) GetDevices(ctx context.Context) (GetDevicesRes, error) {
-	res, err := c.sendGetDevices(ctx)
+// POST /api/animation/delete
+func (c *Client) DeleteAnimations(ctx context.Context, request *DeleteAnimationsRequest) (DeleteAnimationsRes, error) {
+	res, err := c.sendDeleteAnimations(ctx, request)
 	return res, err
 }
 
-func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err error) {
+func (c *Client) sendDeleteAnimations(ctx context.Context, request *DeleteAnimationsRequest) (res DeleteAnimationsRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("getDevices"),
-		semconv.HTTPRequestMethodKey.String("GET"),
-		semconv.URLTemplateKey.String("/api/devices"),
+		otelogen.OperationID("deleteAnimations"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/delete"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
 
@@ -333,7 +468,7 @@ func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err err
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, GetDevicesOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DeleteAnimationsOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -351,14 +486,17 @@ func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err err
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/api/devices"
+	pathParts[0] = "/api/animation/delete"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeDeleteAnimationsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
 
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
@@ -368,7 +506,7 @@ func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err err
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeGetDevicesResponse(resp)
+	result, err := decodeDeleteAnimationsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -376,20 +514,20 @@ func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err err
 	return result, nil
 }
 
-// ListAnimations invokes listAnimations operation.
+// DeleteDeviceAnimations invokes deleteDeviceAnimations operation.
 //
-// Returns all saved animations for the specified device, ordered by most recently updated.
+// Permanently removes every animation saved for the specified device, including their undo history.
 //
-// GET /api/animation/list/{device_id}
-func (c *Client) ListAnimations(ctx context.Context, params ListAnimationsParams) (ListAnimationsRes, error) {
-	res, err := c.sendListAnimations(ctx, params)
+// DELETE /api/animation/list/{device_id}
+func (c *Client) DeleteDeviceAnimations(ctx context.Context, params DeleteDeviceAnimationsParams) (DeleteDeviceAnimationsRes, error) {
+	res, err := c.sendDeleteDeviceAnimations(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsParams) (res ListAnimationsRes, err error) {
+func (c *Client) sendDeleteDeviceAnimations(ctx context.Context, params DeleteDeviceAnimationsParams) (res DeleteDeviceAnimationsRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("listAnimations"),
-		semconv.HTTPRequestMethodKey.String("GET"),
+		otelogen.OperationID("deleteDeviceAnimations"),
+		semconv.HTTPRequestMethodKey.String("DELETE"),
 		semconv.URLTemplateKey.String("/api/animation/list/{device_id}"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
@@ -406,7 +544,7 @@ func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsPa
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, ListAnimationsOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DeleteDeviceAnimationsOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -446,7 +584,7 @@ func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsPa
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "DELETE", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
@@ -459,7 +597,7 @@ func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsPa
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeListAnimationsResponse(resp)
+	result, err := decodeDeleteDeviceAnimationsResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -467,21 +605,22 @@ func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsPa
 	return result, nil
 }
 
-// SaveAnimation invokes saveAnimation operation.
+// DuplicateAnimation invokes duplicateAnimation operation.
 //
-// Saves the current animation frames to the database with a name. Stored per device.
+// Saves a copy of the animation with a new ID, including its frames, timing and tags. The copy
+// starts without undo history.
 //
-// POST /api/animation/save
-func (c *Client) SaveAnimation(ctx context.Context, request *SaveAnimationRequest) (SaveAnimationRes, error) {
-	res, err := c.sendSaveAnimation(ctx, request)
+// POST /api/animation/{id}/duplicate
+func (c *Client) DuplicateAnimation(ctx context.Context, request OptDuplicateAnimationRequest, params DuplicateAnimationParams) (DuplicateAnimationRes, error) {
+	res, err := c.sendDuplicateAnimation(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRequest) (res SaveAnimationRes, err error) {
+func (c *Client) sendDuplicateAnimation(ctx context.Context, request OptDuplicateAnimationRequest, params DuplicateAnimationParams) (res DuplicateAnimationRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("saveAnimation"),
+		otelogen.OperationID("duplicateAnimation"),
 		semconv.HTTPRequestMethodKey.String("POST"),
-		semconv.URLTemplateKey.String("/api/animation/save"),
+		semconv.URLTemplateKey.String("/api/animation/{id}/duplicate"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
 
@@ -497,7 +636,7 @@ func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRe
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, SaveAnimationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, DuplicateAnimationOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -514,8 +653,27 @@ func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRe
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/api/animation/save"
+	var pathParts [3]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/duplicate"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
@@ -523,7 +681,7 @@ func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRe
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeSaveAnimationRequest(request, r); err != nil {
+	if err := encodeDuplicateAnimationRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
@@ -535,7 +693,7 @@ func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRe
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeSaveAnimationResponse(resp)
+	result, err := decodeDuplicateAnimationResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -543,22 +701,23 @@ func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRe
 	return result, nil
 }
 
-// StartAnimation invokes startAnimation operation.
+// ExportAnimationGif invokes exportAnimationGif operation.
 //
-// Starts playing an animation loop on the specified device. Only one animation can run per device at
-// a time.
+// Renders the animation as a looping GIF with every matrix pixel scaled up to a block of pixels.
+// Frames keep their per-frame durations when the animation has them, otherwise they play at the
+// requested fps or the animation's frame duration.
 //
-// POST /api/animation/start
-func (c *Client) StartAnimation(ctx context.Context, request *StartAnimationRequest) (StartAnimationRes, error) {
-	res, err := c.sendStartAnimation(ctx, request)
+// GET /api/animation/{id}/gif
+func (c *Client) ExportAnimationGif(ctx context.Context, params ExportAnimationGifParams) (ExportAnimationGifRes, error) {
+	res, err := c.sendExportAnimationGif(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimationRequest) (res StartAnimationRes, err error) {
+func (c *Client) sendExportAnimationGif(ctx context.Context, params ExportAnimationGifParams) (res ExportAnimationGifRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("startAnimation"),
-		semconv.HTTPRequestMethodKey.String("POST"),
-		semconv.URLTemplateKey.String("/api/animation/start"),
+		otelogen.OperationID("exportAnimationGif"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/animation/{id}/gif"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
 
@@ -574,7 +733,7 @@ func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimation
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, StartAnimationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, ExportAnimationGifOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -591,18 +750,72 @@ func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimation
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/api/animation/start"
+	var pathParts [3]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/gif"
 	uri.AddPathParts(u, pathParts[:]...)
 
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "scale" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "scale",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Scale.Get(); ok {
+				return e.EncodeValue(conv.IntToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "fps" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "fps",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Fps.Get(); ok {
+				return e.EncodeValue(conv.IntToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeStartAnimationRequest(request, r); err != nil {
-		return res, errors.Wrap(err, "encode request")
-	}
 
 	stage = "SendRequest"
 	resp, err := c.cfg.Client.Do(r)
@@ -612,7 +825,7 @@ func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimation
 	defer resp.Body.Close()
 
 	stage = "DecodeResponse"
-	result, err := decodeStartAnimationResponse(resp)
+	result, err := decodeExportAnimationGifResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -620,21 +833,21 @@ func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimation
 	return result, nil
 }
 
-// StopAnimation invokes stopAnimation operation.
+// GetAnimation invokes getAnimation operation.
 //
-// Stops the currently running animation on the specified device. No-op if no animation is running.
+// Retrieves a saved animation by its ID.
 //
-// POST /api/animation/stop
-func (c *Client) StopAnimation(ctx context.Context, request *StopAnimationRequest) (StopAnimationRes, error) {
-	res, err := c.sendStopAnimation(ctx, request)
+// GET /api/animation/{id}
+func (c *Client) GetAnimation(ctx context.Context, params GetAnimationParams) (GetAnimationRes, error) {
+	res, err := c.sendGetAnimation(ctx, params)
 	return res, err
 }
 
-func (c *Client) sendStopAnimation(ctx context.Context, request *StopAnimationRequest) (res StopAnimationRes, err error) {
+func (c *Client) sendGetAnimation(ctx context.Context, params GetAnimationParams) (res GetAnimationRes, err error) {
 	otelAttrs := []attribute.KeyValue{
-		otelogen.OperationID("stopAnimation"),
-		semconv.HTTPRequestMethodKey.String("POST"),
-		semconv.URLTemplateKey.String("/api/animation/stop"),
+		otelogen.OperationID("getAnimation"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/animation/{id}"),
 	}
 	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
 
@@ -650,7 +863,7 @@ func (c *Client) sendStopAnimation(ctx context.Context, request *StopAnimationRe
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, StopAnimationOperation,
+	ctx, span := c.cfg.Tracer.Start(ctx, GetAnimationOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -667,16 +880,1848 @@ func (c *Client) sendStopAnimation(ctx context.Context, request *StopAnimationRe
 
 	stage = "BuildURL"
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [1]string
-	pathParts[0] = "/api/animation/stop"
+	var pathParts [2]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
 	uri.AddPathParts(u, pathParts[:]...)
 
 	stage = "EncodeRequest"
-	r, err := ht.NewRequest(ctx, "POST", u)
+	r, err := ht.NewRequest(ctx, "GET", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeStopAnimationRequest(request, r); err != nil {
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetAnimationThumbnail invokes getAnimationThumbnail operation.
+//
+// Renders the first frame of the animation as a PNG with every matrix pixel scaled up to a block of
+// pixels.
+//
+// GET /api/animation/{id}/thumbnail
+func (c *Client) GetAnimationThumbnail(ctx context.Context, params GetAnimationThumbnailParams) (GetAnimationThumbnailRes, error) {
+	res, err := c.sendGetAnimationThumbnail(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGetAnimationThumbnail(ctx context.Context, params GetAnimationThumbnailParams) (res GetAnimationThumbnailRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getAnimationThumbnail"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/animation/{id}/thumbnail"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetAnimationThumbnailOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/thumbnail"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "scale" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "scale",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Scale.Get(); ok {
+				return e.EncodeValue(conv.IntToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetAnimationThumbnailResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetDeviceStatus invokes getDeviceStatus operation.
+//
+// Queries the device for its current power, brightness and color mode, together with the color modes
+// and color temperature range it supports.
+//
+// GET /api/device/status
+func (c *Client) GetDeviceStatus(ctx context.Context, params GetDeviceStatusParams) (GetDeviceStatusRes, error) {
+	res, err := c.sendGetDeviceStatus(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGetDeviceStatus(ctx context.Context, params GetDeviceStatusParams) (res GetDeviceStatusRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getDeviceStatus"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/device/status"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetDeviceStatusOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/status"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_location" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_location",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.DeviceLocation))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetDeviceStatusResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetDevices invokes getDevices operation.
+//
+// Performs live SSDP discovery and returns the devices that answered, followed by devices seen
+// within the last day that did not answer, marked offline.
+//
+// GET /api/devices
+func (c *Client) GetDevices(ctx context.Context) (GetDevicesRes, error) {
+	res, err := c.sendGetDevices(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetDevices(ctx context.Context) (res GetDevicesRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getDevices"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/devices"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetDevicesOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/devices"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetDevicesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetWhiteBalance invokes getWhiteBalance operation.
+//
+// Returns the per-channel gains applied to every frame sent to the device. Devices without
+// calibration report gains of 1.
+//
+// GET /api/device/white-balance
+func (c *Client) GetWhiteBalance(ctx context.Context, params GetWhiteBalanceParams) (GetWhiteBalanceRes, error) {
+	res, err := c.sendGetWhiteBalance(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGetWhiteBalance(ctx context.Context, params GetWhiteBalanceParams) (res GetWhiteBalanceRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getWhiteBalance"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/device/white-balance"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetWhiteBalanceOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/white-balance"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_location" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_location",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.DeviceLocation))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetWhiteBalanceResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ImportGifAnimation invokes importGifAnimation operation.
+//
+// Decodes the uploaded GIF, fits every frame to the 20x5 matrix by cropping to its aspect ratio and
+// nearest-neighbour scaling, and saves the result like saveAnimation. Frame delays from the GIF
+// become per-frame durations.
+//
+// POST /api/animation/import/gif
+func (c *Client) ImportGifAnimation(ctx context.Context, request ImportGifAnimationReq, params ImportGifAnimationParams) (ImportGifAnimationRes, error) {
+	res, err := c.sendImportGifAnimation(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendImportGifAnimation(ctx context.Context, request ImportGifAnimationReq, params ImportGifAnimationParams) (res ImportGifAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("importGifAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/import/gif"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ImportGifAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/import/gif"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_id" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.DeviceID))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "name" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "name",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.Name))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeImportGifAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeImportGifAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ImportImageAnimation invokes importImageAnimation operation.
+//
+// Decodes the uploaded image, fits it to the 20x5 matrix by cropping to its aspect ratio and
+// averaging, and saves it as a single-frame animation. Transparent areas become black.
+//
+// POST /api/animation/import/image
+func (c *Client) ImportImageAnimation(ctx context.Context, request ImportImageAnimationReq, params ImportImageAnimationParams) (ImportImageAnimationRes, error) {
+	res, err := c.sendImportImageAnimation(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendImportImageAnimation(ctx context.Context, request ImportImageAnimationReq, params ImportImageAnimationParams) (res ImportImageAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("importImageAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/import/image"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ImportImageAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/import/image"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_id" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_id",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.DeviceID))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "name" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "name",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.Name))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeImportImageAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeImportImageAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListAnimations invokes listAnimations operation.
+//
+// Returns the saved animations for the specified device, by default ordered by most recently updated.
+//
+//	Results can be filtered by name, tag and creation time and sorted by name or date.
+//
+// GET /api/animation/list/{device_id}
+func (c *Client) ListAnimations(ctx context.Context, params ListAnimationsParams) (ListAnimationsRes, error) {
+	res, err := c.sendListAnimations(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendListAnimations(ctx context.Context, params ListAnimationsParams) (res ListAnimationsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("listAnimations"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/animation/list/{device_id}"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ListAnimationsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/api/animation/list/"
+	{
+		// Encode "device_id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "device_id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.DeviceID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "q" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "q",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Q.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "tag" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "tag",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Tag.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "sort" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "sort",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Sort.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "order" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "order",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Order.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "created_after" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "created_after",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.CreatedAfter.Get(); ok {
+				return e.EncodeValue(conv.DateTimeToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "created_before" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "created_before",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.CreatedBefore.Get(); ok {
+				return e.EncodeValue(conv.DateTimeToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeListAnimationsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListRunningAnimations invokes listRunningAnimations operation.
+//
+// Reports every device with an animation playing, including previews, with its playback position.
+// Useful to find stale animations still driving a device.
+//
+// GET /api/animation/running
+func (c *Client) ListRunningAnimations(ctx context.Context) (*RunningAnimationsResponse, error) {
+	res, err := c.sendListRunningAnimations(ctx)
+	return res, err
+}
+
+func (c *Client) sendListRunningAnimations(ctx context.Context) (res *RunningAnimationsResponse, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("listRunningAnimations"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/animation/running"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ListRunningAnimationsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/running"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeListRunningAnimationsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// PauseAnimation invokes pauseAnimation operation.
+//
+// Freezes the animation running on the specified device on its current frame, keeping its position
+// so it can be resumed.
+//
+// POST /api/animation/pause
+func (c *Client) PauseAnimation(ctx context.Context, request *PauseAnimationRequest) (PauseAnimationRes, error) {
+	res, err := c.sendPauseAnimation(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendPauseAnimation(ctx context.Context, request *PauseAnimationRequest) (res PauseAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("pauseAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/pause"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, PauseAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/pause"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodePauseAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodePauseAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// PingDevice invokes pingDevice operation.
+//
+// Reports whether the device accepts connections. Results are cached for a short time and refreshed
+// in the background, so frequent polling does not dial the device on every request.
+//
+// GET /api/device/ping
+func (c *Client) PingDevice(ctx context.Context, params PingDeviceParams) (*PingDeviceResponse, error) {
+	res, err := c.sendPingDevice(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendPingDevice(ctx context.Context, params PingDeviceParams) (res *PingDeviceResponse, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("pingDevice"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/api/device/ping"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, PingDeviceOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/ping"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeQueryParams"
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "device_location" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "device_location",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.DeviceLocation))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodePingDeviceResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RedoAnimation invokes redoAnimation operation.
+//
+// Reapplies the last update reverted by undo. Redo history is discarded when the animation is
+// updated.
+//
+// POST /api/animation/{id}/redo
+func (c *Client) RedoAnimation(ctx context.Context, params RedoAnimationParams) (RedoAnimationRes, error) {
+	res, err := c.sendRedoAnimation(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendRedoAnimation(ctx context.Context, params RedoAnimationParams) (res RedoAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("redoAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/{id}/redo"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, RedoAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/redo"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeRedoAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ResumeAnimation invokes resumeAnimation operation.
+//
+// Continues a paused animation on the specified device with the frame after the one it was paused on.
+//
+// POST /api/animation/resume
+func (c *Client) ResumeAnimation(ctx context.Context, request *ResumeAnimationRequest) (ResumeAnimationRes, error) {
+	res, err := c.sendResumeAnimation(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendResumeAnimation(ctx context.Context, request *ResumeAnimationRequest) (res ResumeAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("resumeAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/resume"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ResumeAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/resume"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeResumeAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeResumeAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SaveAnimation invokes saveAnimation operation.
+//
+// Saves the current animation frames to the database with a name. Stored per device.
+//
+// POST /api/animation/save
+func (c *Client) SaveAnimation(ctx context.Context, request *SaveAnimationRequest) (SaveAnimationRes, error) {
+	res, err := c.sendSaveAnimation(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendSaveAnimation(ctx context.Context, request *SaveAnimationRequest) (res SaveAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("saveAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/save"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, SaveAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/save"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSaveAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSaveAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SetDeviceBrightness invokes setDeviceBrightness operation.
+//
+// Sets the device brightness in percent.
+//
+// POST /api/device/brightness
+func (c *Client) SetDeviceBrightness(ctx context.Context, request *SetDeviceBrightnessRequest) (SetDeviceBrightnessRes, error) {
+	res, err := c.sendSetDeviceBrightness(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendSetDeviceBrightness(ctx context.Context, request *SetDeviceBrightnessRequest) (res SetDeviceBrightnessRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("setDeviceBrightness"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/device/brightness"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, SetDeviceBrightnessOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/brightness"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSetDeviceBrightnessRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSetDeviceBrightnessResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SetDeviceName invokes setDeviceName operation.
+//
+// Sets the device name and reads it back to confirm the device stored it, since some firmware
+// acknowledges the change without applying it.
+//
+// POST /api/device/name
+func (c *Client) SetDeviceName(ctx context.Context, request *SetDeviceNameRequest) (SetDeviceNameRes, error) {
+	res, err := c.sendSetDeviceName(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendSetDeviceName(ctx context.Context, request *SetDeviceNameRequest) (res SetDeviceNameRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("setDeviceName"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/device/name"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, SetDeviceNameOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/name"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSetDeviceNameRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSetDeviceNameResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SetDevicePower invokes setDevicePower operation.
+//
+// Sets the device power state. Unlike toggling, the result does not depend on the current state.
+//
+// POST /api/device/power
+func (c *Client) SetDevicePower(ctx context.Context, request *SetDevicePowerRequest) (SetDevicePowerRes, error) {
+	res, err := c.sendSetDevicePower(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendSetDevicePower(ctx context.Context, request *SetDevicePowerRequest) (res SetDevicePowerRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("setDevicePower"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/device/power"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, SetDevicePowerOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/power"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSetDevicePowerRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSetDevicePowerResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// SetWhiteBalance invokes setWhiteBalance operation.
+//
+// Persists per-channel gains that correct the device's color cast. The gains apply to all subsequent
+// frames, including those of a running animation.
+//
+// PUT /api/device/white-balance
+func (c *Client) SetWhiteBalance(ctx context.Context, request *SetWhiteBalanceRequest) (SetWhiteBalanceRes, error) {
+	res, err := c.sendSetWhiteBalance(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendSetWhiteBalance(ctx context.Context, request *SetWhiteBalanceRequest) (res SetWhiteBalanceRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("setWhiteBalance"),
+		semconv.HTTPRequestMethodKey.String("PUT"),
+		semconv.URLTemplateKey.String("/api/device/white-balance"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, SetWhiteBalanceOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/device/white-balance"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PUT", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeSetWhiteBalanceRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeSetWhiteBalanceResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StartAnimation invokes startAnimation operation.
+//
+// Starts playing an animation loop on the specified device. Only one animation can run per device at
+// a time.
+//
+// POST /api/animation/start
+func (c *Client) StartAnimation(ctx context.Context, request *StartAnimationRequest) (StartAnimationRes, error) {
+	res, err := c.sendStartAnimation(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendStartAnimation(ctx context.Context, request *StartAnimationRequest) (res StartAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("startAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/start"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, StartAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/start"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeStartAnimationRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeStartAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StartPlaylist invokes startPlaylist operation.
+//
+// Plays saved animations one after another on the specified device, switching to the next one after
+// the per-item duration and looping back to the first after the last. The animations are given
+// either as a list of IDs or as a tag, which selects all of a device's animations with that tag in
+// the order they were created. Replaces any animation currently running on the device and is stopped
+// like a single animation.
+//
+// POST /api/animation/playlist
+func (c *Client) StartPlaylist(ctx context.Context, request *StartPlaylistRequest) (StartPlaylistRes, error) {
+	res, err := c.sendStartPlaylist(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendStartPlaylist(ctx context.Context, request *StartPlaylistRequest) (res StartPlaylistRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("startPlaylist"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/playlist"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, StartPlaylistOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/playlist"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeStartPlaylistRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeStartPlaylistResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StopAnimation invokes stopAnimation operation.
+//
+// Stops the currently running animation on the specified device. No-op if no animation is running.
+//
+// POST /api/animation/stop
+func (c *Client) StopAnimation(ctx context.Context, request *StopAnimationRequest) (StopAnimationRes, error) {
+	res, err := c.sendStopAnimation(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendStopAnimation(ctx context.Context, request *StopAnimationRequest) (res StopAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("stopAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/stop"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, StopAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/api/animation/stop"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeStopAnimationRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
@@ -696,6 +2741,99 @@ func (c *Client) sendStopAnimation(ctx context.Context, request *StopAnimationRe
 	return result, nil
 }
 
+// UndoAnimation invokes undoAnimation operation.
+//
+// Restores the animation to its state before the last update. Up to 20 previous states are kept per
+// animation.
+//
+// POST /api/animation/{id}/undo
+func (c *Client) UndoAnimation(ctx context.Context, params UndoAnimationParams) (UndoAnimationRes, error) {
+	res, err := c.sendUndoAnimation(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendUndoAnimation(ctx context.Context, params UndoAnimationParams) (res UndoAnimationRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("undoAnimation"),
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.URLTemplateKey.String("/api/animation/{id}/undo"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, UndoAnimationOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/animation/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/undo"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeUndoAnimationResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // UpdateAnimation invokes updateAnimation operation.
 //
 // Overwrites an existing animation's name and frames.
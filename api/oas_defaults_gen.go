@@ -0,0 +1,35 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package api
+
+// setDefaults set default value of fields.
+func (s *SaveAnimationRequest) setDefaults() {
+	{
+		val := int(1000)
+		s.FrameDurationMs.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *StartAnimationRequest) setDefaults() {
+	{
+		val := bool(false)
+		s.Preview.SetTo(val)
+	}
+	{
+		val := StartAnimationRequestPlaybackMode("forward")
+		s.PlaybackMode.SetTo(val)
+	}
+	{
+		val := int(0)
+		s.LoopCount.SetTo(val)
+	}
+	{
+		val := int(0)
+		s.TransitionSteps.SetTo(val)
+	}
+	{
+		val := bool(false)
+		s.ClearOnStop.SetTo(val)
+	}
+}
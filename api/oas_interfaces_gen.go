@@ -1,34 +1,114 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type ApplyDeviceStateRes interface {
+	applyDeviceStateRes()
+}
+
 type DeleteAnimationRes interface {
 	deleteAnimationRes()
 }
 
+type DeleteAnimationsRes interface {
+	deleteAnimationsRes()
+}
+
+type DeleteDeviceAnimationsRes interface {
+	deleteDeviceAnimationsRes()
+}
+
+type DuplicateAnimationRes interface {
+	duplicateAnimationRes()
+}
+
+type ExportAnimationGifRes interface {
+	exportAnimationGifRes()
+}
+
 type GetAnimationRes interface {
 	getAnimationRes()
 }
 
+type GetAnimationThumbnailRes interface {
+	getAnimationThumbnailRes()
+}
+
+type GetDeviceStatusRes interface {
+	getDeviceStatusRes()
+}
+
 type GetDevicesRes interface {
 	getDevicesRes()
 }
 
+type GetWhiteBalanceRes interface {
+	getWhiteBalanceRes()
+}
+
+type ImportGifAnimationRes interface {
+	importGifAnimationRes()
+}
+
+type ImportImageAnimationReq interface {
+	importImageAnimationReq()
+}
+
+type ImportImageAnimationRes interface {
+	importImageAnimationRes()
+}
+
 type ListAnimationsRes interface {
 	listAnimationsRes()
 }
 
+type PauseAnimationRes interface {
+	pauseAnimationRes()
+}
+
+type RedoAnimationRes interface {
+	redoAnimationRes()
+}
+
+type ResumeAnimationRes interface {
+	resumeAnimationRes()
+}
+
 type SaveAnimationRes interface {
 	saveAnimationRes()
 }
 
+type SetDeviceBrightnessRes interface {
+	setDeviceBrightnessRes()
+}
+
+type SetDeviceNameRes interface {
+	setDeviceNameRes()
+}
+
+type SetDevicePowerRes interface {
+	setDevicePowerRes()
+}
+
+type SetWhiteBalanceRes interface {
+	setWhiteBalanceRes()
+}
+
 type StartAnimationRes interface {
 	startAnimationRes()
 }
 
+type StartPlaylistRes interface {
+	startPlaylistRes()
+}
+
 type StopAnimationRes interface {
 	stopAnimationRes()
 }
 
+type UndoAnimationRes interface {
+	undoAnimationRes()
+}
+
 type UpdateAnimationRes interface {
 	updateAnimationRes()
 }
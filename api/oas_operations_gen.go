@@ -6,12 +6,33 @@ package api
 type OperationName = string
 
 const (
-	DeleteAnimationOperation OperationName = "DeleteAnimation"
-	GetAnimationOperation    OperationName = "GetAnimation"
-	GetDevicesOperation      OperationName = "GetDevices"
-	ListAnimationsOperation  OperationName = "ListAnimations"
-	SaveAnimationOperation   OperationName = "SaveAnimation"
-	StartAnimationOperation  OperationName = "StartAnimation"
-	StopAnimationOperation   OperationName = "StopAnimation"
-	UpdateAnimationOperation OperationName = "UpdateAnimation"
+	ApplyDeviceStateOperation       OperationName = "ApplyDeviceState"
+	DeleteAnimationOperation        OperationName = "DeleteAnimation"
+	DeleteAnimationsOperation       OperationName = "DeleteAnimations"
+	DeleteDeviceAnimationsOperation OperationName = "DeleteDeviceAnimations"
+	DuplicateAnimationOperation     OperationName = "DuplicateAnimation"
+	ExportAnimationGifOperation     OperationName = "ExportAnimationGif"
+	GetAnimationOperation           OperationName = "GetAnimation"
+	GetAnimationThumbnailOperation  OperationName = "GetAnimationThumbnail"
+	GetDeviceStatusOperation        OperationName = "GetDeviceStatus"
+	GetDevicesOperation             OperationName = "GetDevices"
+	GetWhiteBalanceOperation        OperationName = "GetWhiteBalance"
+	ImportGifAnimationOperation     OperationName = "ImportGifAnimation"
+	ImportImageAnimationOperation   OperationName = "ImportImageAnimation"
+	ListAnimationsOperation         OperationName = "ListAnimations"
+	ListRunningAnimationsOperation  OperationName = "ListRunningAnimations"
+	PauseAnimationOperation         OperationName = "PauseAnimation"
+	PingDeviceOperation             OperationName = "PingDevice"
+	RedoAnimationOperation          OperationName = "RedoAnimation"
+	ResumeAnimationOperation        OperationName = "ResumeAnimation"
+	SaveAnimationOperation          OperationName = "SaveAnimation"
+	SetDeviceBrightnessOperation    OperationName = "SetDeviceBrightness"
+	SetDeviceNameOperation          OperationName = "SetDeviceName"
+	SetDevicePowerOperation         OperationName = "SetDevicePower"
+	SetWhiteBalanceOperation        OperationName = "SetWhiteBalance"
+	StartAnimationOperation         OperationName = "StartAnimation"
+	StartPlaylistOperation          OperationName = "StartPlaylist"
+	StopAnimationOperation          OperationName = "StopAnimation"
+	UndoAnimationOperation          OperationName = "UndoAnimation"
+	UpdateAnimationOperation        OperationName = "UpdateAnimation"
 )
@@ -6,10 +6,117 @@ import (
 	"bytes"
 	"net/http"
 
+	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
 	ht "github.com/ogen-go/ogen/http"
 )
 
+func encodeApplyDeviceStateRequest(
+	req *ApplyDeviceStateRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeDeleteAnimationsRequest(
+	req *DeleteAnimationsRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeDuplicateAnimationRequest(
+	req OptDuplicateAnimationRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	if !req.Set {
+		// Keep request with empty body if value is not set.
+		return nil
+	}
+	e := new(jx.Encoder)
+	{
+		if req.Set {
+			req.Encode(e)
+		}
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeImportGifAnimationRequest(
+	req ImportGifAnimationReq,
+	r *http.Request,
+) error {
+	const contentType = "image/gif"
+	body := req
+	ht.SetBody(r, body, contentType)
+	return nil
+}
+
+func encodeImportImageAnimationRequest(
+	req ImportImageAnimationReq,
+	r *http.Request,
+) error {
+	switch req := req.(type) {
+	case *ImportImageAnimationReqImageJpeg:
+		const contentType = "image/jpeg"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	case *ImportImageAnimationReqImagePNG:
+		const contentType = "image/png"
+		body := req
+		ht.SetBody(r, body, contentType)
+		return nil
+	default:
+		return errors.Errorf("unexpected request type: %T", req)
+	}
+}
+
+func encodePauseAnimationRequest(
+	req *PauseAnimationRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeResumeAnimationRequest(
+	req *ResumeAnimationRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeSaveAnimationRequest(
 	req *SaveAnimationRequest,
 	r *http.Request,
@@ -24,6 +131,62 @@ func encodeSaveAnimationRequest(
 	return nil
 }
 
+func encodeSetDeviceBrightnessRequest(
+	req *SetDeviceBrightnessRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeSetDeviceNameRequest(
+	req *SetDeviceNameRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeSetDevicePowerRequest(
+	req *SetDevicePowerRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
+func encodeSetWhiteBalanceRequest(
+	req *SetWhiteBalanceRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeStartAnimationRequest(
 	req *StartAnimationRequest,
 	r *http.Request,
@@ -38,6 +201,20 @@ func encodeStartAnimationRequest(
 	return nil
 }
 
+func encodeStartPlaylistRequest(
+	req *StartPlaylistRequest,
+	r *http.Request,
+) error {
+	const contentType = "application/json"
+	e := new(jx.Encoder)
+	{
+		req.Encode(e)
+	}
+	encoded := e.Bytes()
+	ht.SetBody(r, bytes.NewReader(encoded), contentType)
+	return nil
+}
+
 func encodeStopAnimationRequest(
 	req *StopAnimationRequest,
 	r *http.Request,
@@ -73,6 +73,82 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					break
 				}
 				switch elem[0] {
+				case 'd': // Prefix: "delete"
+					origElem := elem
+					if l := len("delete"); len(elem) >= l && elem[0:l] == "delete" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "POST":
+							s.handleDeleteAnimationsRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "POST")
+						}
+
+						return
+					}
+
+					elem = origElem
+				case 'i': // Prefix: "import/"
+					origElem := elem
+					if l := len("import/"); len(elem) >= l && elem[0:l] == "import/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'g': // Prefix: "gif"
+
+						if l := len("gif"); len(elem) >= l && elem[0:l] == "gif" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleImportGifAnimationRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'i': // Prefix: "image"
+
+						if l := len("image"); len(elem) >= l && elem[0:l] == "image" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleImportImageAnimationRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					}
+
+					elem = origElem
 				case 'l': // Prefix: "list/"
 					origElem := elem
 					if l := len("list/"); len(elem) >= l && elem[0:l] == "list/" {
@@ -93,17 +169,131 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					if len(elem) == 0 {
 						// Leaf node.
 						switch r.Method {
+						case "DELETE":
+							s.handleDeleteDeviceAnimationsRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
 						case "GET":
 							s.handleListAnimationsRequest([1]string{
 								args[0],
 							}, elemIsEscaped, w, r)
 						default:
-							s.notAllowed(w, r, "GET")
+							s.notAllowed(w, r, "DELETE,GET")
 						}
 
 						return
 					}
 
+					elem = origElem
+				case 'p': // Prefix: "p"
+					origElem := elem
+					if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "ause"
+
+						if l := len("ause"); len(elem) >= l && elem[0:l] == "ause" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handlePauseAnimationRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'l': // Prefix: "laylist"
+
+						if l := len("laylist"); len(elem) >= l && elem[0:l] == "laylist" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleStartPlaylistRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					}
+
+					elem = origElem
+				case 'r': // Prefix: "r"
+					origElem := elem
+					if l := len("r"); len(elem) >= l && elem[0:l] == "r" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'e': // Prefix: "esume"
+
+						if l := len("esume"); len(elem) >= l && elem[0:l] == "esume" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleResumeAnimationRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'u': // Prefix: "unning"
+
+						if l := len("unning"); len(elem) >= l && elem[0:l] == "unning" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleListRunningAnimationsRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					}
+
 					elem = origElem
 				case 's': // Prefix: "s"
 					origElem := elem
@@ -196,16 +386,15 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					elem = origElem
 				}
 				// Param: "id"
-				// Leaf parameter, slashes are prohibited
+				// Match until "/"
 				idx := strings.IndexByte(elem, '/')
-				if idx >= 0 {
-					break
+				if idx < 0 {
+					idx = len(elem)
 				}
-				args[0] = elem
-				elem = ""
+				args[0] = elem[:idx]
+				elem = elem[idx:]
 
 				if len(elem) == 0 {
-					// Leaf node.
 					switch r.Method {
 					case "DELETE":
 						s.handleDeleteAnimationRequest([1]string{
@@ -225,130 +414,1020 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 					return
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
 
-			case 'd': // Prefix: "devices"
-
-				if l := len("devices"); len(elem) >= l && elem[0:l] == "devices" {
-					elem = elem[l:]
-				} else {
-					break
-				}
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
 
-				if len(elem) == 0 {
-					// Leaf node.
-					switch r.Method {
-					case "GET":
-						s.handleGetDevicesRequest([0]string{}, elemIsEscaped, w, r)
-					default:
-						s.notAllowed(w, r, "GET")
+					if len(elem) == 0 {
+						break
 					}
+					switch elem[0] {
+					case 'd': // Prefix: "duplicate"
 
-					return
-				}
+						if l := len("duplicate"); len(elem) >= l && elem[0:l] == "duplicate" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-			}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleDuplicateAnimationRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
 
-		}
-	}
-	s.notFound(w, r)
-}
+							return
+						}
 
-// Route is route object.
-type Route struct {
-	name           string
-	summary        string
-	operationID    string
-	operationGroup string
-	pathPattern    string
-	count          int
-	args           [1]string
-}
+					case 'g': // Prefix: "gif"
 
-// Name returns ogen operation name.
-//
-// It is guaranteed to be unique and not empty.
-func (r Route) Name() string {
-	return r.name
-}
+						if l := len("gif"); len(elem) >= l && elem[0:l] == "gif" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-// Summary returns OpenAPI summary.
-func (r Route) Summary() string {
-	return r.summary
-}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleExportAnimationGifRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
 
-// OperationID returns OpenAPI operationId.
-func (r Route) OperationID() string {
-	return r.operationID
-}
+							return
+						}
 
-// OperationGroup returns the x-ogen-operation-group value.
-func (r Route) OperationGroup() string {
-	return r.operationGroup
-}
+					case 'r': // Prefix: "redo"
 
-// PathPattern returns OpenAPI path.
-func (r Route) PathPattern() string {
-	return r.pathPattern
-}
+						if l := len("redo"); len(elem) >= l && elem[0:l] == "redo" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-// Args returns parsed arguments.
-func (r Route) Args() []string {
-	return r.args[:r.count]
-}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleRedoAnimationRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
 
-// FindRoute finds Route for given method and path.
-//
-// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
-func (s *Server) FindRoute(method, path string) (Route, bool) {
-	return s.FindPath(method, &url.URL{Path: path})
-}
+							return
+						}
+
+					case 't': // Prefix: "thumbnail"
+
+						if l := len("thumbnail"); len(elem) >= l && elem[0:l] == "thumbnail" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetAnimationThumbnailRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+					case 'u': // Prefix: "undo"
+
+						if l := len("undo"); len(elem) >= l && elem[0:l] == "undo" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleUndoAnimationRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					}
+
+				}
+
+			case 'd': // Prefix: "device"
+
+				if l := len("device"); len(elem) >= l && elem[0:l] == "device" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "brightness"
+
+						if l := len("brightness"); len(elem) >= l && elem[0:l] == "brightness" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleSetDeviceBrightnessRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'n': // Prefix: "name"
+
+						if l := len("name"); len(elem) >= l && elem[0:l] == "name" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleSetDeviceNameRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+					case 'p': // Prefix: "p"
+
+						if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'i': // Prefix: "ing"
+
+							if l := len("ing"); len(elem) >= l && elem[0:l] == "ing" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handlePingDeviceRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						case 'o': // Prefix: "ower"
+
+							if l := len("ower"); len(elem) >= l && elem[0:l] == "ower" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleSetDevicePowerRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+
+						}
+
+					case 's': // Prefix: "stat"
+
+						if l := len("stat"); len(elem) >= l && elem[0:l] == "stat" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "e"
+
+							if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "POST":
+									s.handleApplyDeviceStateRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "POST")
+								}
+
+								return
+							}
+
+						case 'u': // Prefix: "us"
+
+							if l := len("us"); len(elem) >= l && elem[0:l] == "us" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetDeviceStatusRequest([0]string{}, elemIsEscaped, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+
+						}
+
+					case 'w': // Prefix: "white-balance"
+
+						if l := len("white-balance"); len(elem) >= l && elem[0:l] == "white-balance" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetWhiteBalanceRequest([0]string{}, elemIsEscaped, w, r)
+							case "PUT":
+								s.handleSetWhiteBalanceRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET,PUT")
+							}
+
+							return
+						}
+
+					}
+
+				case 's': // Prefix: "s"
+
+					if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetDevicesRequest([0]string{}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
 
-// FindPath finds Route for given method and URL.
-func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
-	var (
-		elem = u.Path
-		args = r.args
-	)
-	if rawPath := u.RawPath; rawPath != "" {
-		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
-			elem = normalized
-		}
-		defer func() {
-			for i, arg := range r.args[:r.count] {
-				if unescaped, err := url.PathUnescape(arg); err == nil {
-					r.args[i] = unescaped
 				}
+
 			}
-		}()
+
+		}
 	}
+	s.notFound(w, r)
+}
+
+// Route is route object.
+type Route struct {
+	name           string
+	summary        string
+	operationID    string
+	operationGroup string
+	pathPattern    string
+	count          int
+	args           [1]string
+}
+
+// Name returns ogen operation name.
+//
+// It is guaranteed to be unique and not empty.
+func (r Route) Name() string {
+	return r.name
+}
+
+// Summary returns OpenAPI summary.
+func (r Route) Summary() string {
+	return r.summary
+}
+
+// OperationID returns OpenAPI operationId.
+func (r Route) OperationID() string {
+	return r.operationID
+}
+
+// OperationGroup returns the x-ogen-operation-group value.
+func (r Route) OperationGroup() string {
+	return r.operationGroup
+}
+
+// PathPattern returns OpenAPI path.
+func (r Route) PathPattern() string {
+	return r.pathPattern
+}
+
+// Args returns parsed arguments.
+func (r Route) Args() []string {
+	return r.args[:r.count]
+}
+
+// FindRoute finds Route for given method and path.
+//
+// Note: this method does not unescape path or handle reserved characters in path properly. Use FindPath instead.
+func (s *Server) FindRoute(method, path string) (Route, bool) {
+	return s.FindPath(method, &url.URL{Path: path})
+}
+
+// FindPath finds Route for given method and URL.
+func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
+	var (
+		elem = u.Path
+		args = r.args
+	)
+	if rawPath := u.RawPath; rawPath != "" {
+		if normalized, ok := uri.NormalizeEscapedPath(rawPath); ok {
+			elem = normalized
+		}
+		defer func() {
+			for i, arg := range r.args[:r.count] {
+				if unescaped, err := url.PathUnescape(arg); err == nil {
+					r.args[i] = unescaped
+				}
+			}
+		}()
+	}
+
+	elem, ok := s.cutPrefix(elem)
+	if !ok {
+		return r, false
+	}
+
+	// Static code generated router with unwrapped path search.
+	switch {
+	default:
+		if len(elem) == 0 {
+			break
+		}
+		switch elem[0] {
+		case '/': // Prefix: "/api/"
+
+			if l := len("/api/"); len(elem) >= l && elem[0:l] == "/api/" {
+				elem = elem[l:]
+			} else {
+				break
+			}
+
+			if len(elem) == 0 {
+				break
+			}
+			switch elem[0] {
+			case 'a': // Prefix: "animation/"
+
+				if l := len("animation/"); len(elem) >= l && elem[0:l] == "animation/" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'd': // Prefix: "delete"
+					origElem := elem
+					if l := len("delete"); len(elem) >= l && elem[0:l] == "delete" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "POST":
+							r.name = DeleteAnimationsOperation
+							r.summary = "Delete several saved animations"
+							r.operationID = "deleteAnimations"
+							r.operationGroup = ""
+							r.pathPattern = "/api/animation/delete"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+
+					elem = origElem
+				case 'i': // Prefix: "import/"
+					origElem := elem
+					if l := len("import/"); len(elem) >= l && elem[0:l] == "import/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'g': // Prefix: "gif"
+
+						if l := len("gif"); len(elem) >= l && elem[0:l] == "gif" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ImportGifAnimationOperation
+								r.summary = "Import an animated GIF as a saved animation"
+								r.operationID = "importGifAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/import/gif"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'i': // Prefix: "image"
+
+						if l := len("image"); len(elem) >= l && elem[0:l] == "image" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ImportImageAnimationOperation
+								r.summary = "Import a PNG or JPEG as a single-frame animation"
+								r.operationID = "importImageAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/import/image"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+					elem = origElem
+				case 'l': // Prefix: "list/"
+					origElem := elem
+					if l := len("list/"); len(elem) >= l && elem[0:l] == "list/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "device_id"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "DELETE":
+							r.name = DeleteDeviceAnimationsOperation
+							r.summary = "Delete all saved animations for a device"
+							r.operationID = "deleteDeviceAnimations"
+							r.operationGroup = ""
+							r.pathPattern = "/api/animation/list/{device_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						case "GET":
+							r.name = ListAnimationsOperation
+							r.summary = "List saved animations for a device"
+							r.operationID = "listAnimations"
+							r.operationGroup = ""
+							r.pathPattern = "/api/animation/list/{device_id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+					elem = origElem
+				case 'p': // Prefix: "p"
+					origElem := elem
+					if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "ause"
+
+						if l := len("ause"); len(elem) >= l && elem[0:l] == "ause" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = PauseAnimationOperation
+								r.summary = "Pause animation playback on device"
+								r.operationID = "pauseAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/pause"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'l': // Prefix: "laylist"
+
+						if l := len("laylist"); len(elem) >= l && elem[0:l] == "laylist" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = StartPlaylistOperation
+								r.summary = "Start playlist playback on device"
+								r.operationID = "startPlaylist"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/playlist"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+					elem = origElem
+				case 'r': // Prefix: "r"
+					origElem := elem
+					if l := len("r"); len(elem) >= l && elem[0:l] == "r" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'e': // Prefix: "esume"
+
+						if l := len("esume"); len(elem) >= l && elem[0:l] == "esume" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = ResumeAnimationOperation
+								r.summary = "Resume animation playback on device"
+								r.operationID = "resumeAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/resume"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'u': // Prefix: "unning"
+
+						if l := len("unning"); len(elem) >= l && elem[0:l] == "unning" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ListRunningAnimationsOperation
+								r.summary = "List running animations"
+								r.operationID = "listRunningAnimations"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/running"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					}
+
+					elem = origElem
+				case 's': // Prefix: "s"
+					origElem := elem
+					if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "ave"
+
+						if l := len("ave"); len(elem) >= l && elem[0:l] == "ave" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = SaveAnimationOperation
+								r.summary = "Save animation to database"
+								r.operationID = "saveAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/save"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 't': // Prefix: "t"
+
+						if l := len("t"); len(elem) >= l && elem[0:l] == "t" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'a': // Prefix: "art"
+
+							if l := len("art"); len(elem) >= l && elem[0:l] == "art" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = StartAnimationOperation
+									r.summary = "Start animation playback on device"
+									r.operationID = "startAnimation"
+									r.operationGroup = ""
+									r.pathPattern = "/api/animation/start"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'o': // Prefix: "op"
+
+							if l := len("op"); len(elem) >= l && elem[0:l] == "op" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "POST":
+									r.name = StopAnimationOperation
+									r.summary = "Stop animation playback on device"
+									r.operationID = "stopAnimation"
+									r.operationGroup = ""
+									r.pathPattern = "/api/animation/stop"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						}
+
+					}
+
+					elem = origElem
+				}
+				// Param: "id"
+				// Match until "/"
+				idx := strings.IndexByte(elem, '/')
+				if idx < 0 {
+					idx = len(elem)
+				}
+				args[0] = elem[:idx]
+				elem = elem[idx:]
+
+				if len(elem) == 0 {
+					switch method {
+					case "DELETE":
+						r.name = DeleteAnimationOperation
+						r.summary = "Delete a saved animation"
+						r.operationID = "deleteAnimation"
+						r.operationGroup = ""
+						r.pathPattern = "/api/animation/{id}"
+						r.args = args
+						r.count = 1
+						return r, true
+					case "GET":
+						r.name = GetAnimationOperation
+						r.summary = "Get a specific saved animation"
+						r.operationID = "getAnimation"
+						r.operationGroup = ""
+						r.pathPattern = "/api/animation/{id}"
+						r.args = args
+						r.count = 1
+						return r, true
+					case "PUT":
+						r.name = UpdateAnimationOperation
+						r.summary = "Update an existing saved animation"
+						r.operationID = "updateAnimation"
+						r.operationGroup = ""
+						r.pathPattern = "/api/animation/{id}"
+						r.args = args
+						r.count = 1
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'd': // Prefix: "duplicate"
+
+						if l := len("duplicate"); len(elem) >= l && elem[0:l] == "duplicate" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = DuplicateAnimationOperation
+								r.summary = "Duplicate a saved animation"
+								r.operationID = "duplicateAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/{id}/duplicate"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'g': // Prefix: "gif"
+
+						if l := len("gif"); len(elem) >= l && elem[0:l] == "gif" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = ExportAnimationGifOperation
+								r.summary = "Export a saved animation as an animated GIF"
+								r.operationID = "exportAnimationGif"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/{id}/gif"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 'r': // Prefix: "redo"
+
+						if l := len("redo"); len(elem) >= l && elem[0:l] == "redo" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = RedoAnimationOperation
+								r.summary = "Redo an undone animation update"
+								r.operationID = "redoAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/{id}/redo"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+					case 't': // Prefix: "thumbnail"
+
+						if l := len("thumbnail"); len(elem) >= l && elem[0:l] == "thumbnail" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetAnimationThumbnailOperation
+								r.summary = "Get a thumbnail of a saved animation"
+								r.operationID = "getAnimationThumbnail"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/{id}/thumbnail"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
 
-	elem, ok := s.cutPrefix(elem)
-	if !ok {
-		return r, false
-	}
+					case 'u': // Prefix: "undo"
 
-	// Static code generated router with unwrapped path search.
-	switch {
-	default:
-		if len(elem) == 0 {
-			break
-		}
-		switch elem[0] {
-		case '/': // Prefix: "/api/"
+						if l := len("undo"); len(elem) >= l && elem[0:l] == "undo" {
+							elem = elem[l:]
+						} else {
+							break
+						}
 
-			if l := len("/api/"); len(elem) >= l && elem[0:l] == "/api/" {
-				elem = elem[l:]
-			} else {
-				break
-			}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = UndoAnimationOperation
+								r.summary = "Undo the last animation update"
+								r.operationID = "undoAnimation"
+								r.operationGroup = ""
+								r.pathPattern = "/api/animation/{id}/undo"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
 
-			if len(elem) == 0 {
-				break
-			}
-			switch elem[0] {
-			case 'a': // Prefix: "animation/"
+					}
 
-				if l := len("animation/"); len(elem) >= l && elem[0:l] == "animation/" {
+				}
+
+			case 'd': // Prefix: "device"
+
+				if l := len("device"); len(elem) >= l && elem[0:l] == "device" {
 					elem = elem[l:]
 				} else {
 					break
@@ -358,56 +1437,46 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					break
 				}
 				switch elem[0] {
-				case 'l': // Prefix: "list/"
-					origElem := elem
-					if l := len("list/"); len(elem) >= l && elem[0:l] == "list/" {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
-					// Param: "device_id"
-					// Leaf parameter, slashes are prohibited
-					idx := strings.IndexByte(elem, '/')
-					if idx >= 0 {
+					if len(elem) == 0 {
 						break
 					}
-					args[0] = elem
-					elem = ""
+					switch elem[0] {
+					case 'b': // Prefix: "brightness"
 
-					if len(elem) == 0 {
-						// Leaf node.
-						switch method {
-						case "GET":
-							r.name = ListAnimationsOperation
-							r.summary = "List saved animations for a device"
-							r.operationID = "listAnimations"
-							r.operationGroup = ""
-							r.pathPattern = "/api/animation/list/{device_id}"
-							r.args = args
-							r.count = 1
-							return r, true
-						default:
-							return
+						if l := len("brightness"); len(elem) >= l && elem[0:l] == "brightness" {
+							elem = elem[l:]
+						} else {
+							break
 						}
-					}
 
-					elem = origElem
-				case 's': // Prefix: "s"
-					origElem := elem
-					if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
-						elem = elem[l:]
-					} else {
-						break
-					}
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = SetDeviceBrightnessOperation
+								r.summary = "Set device brightness"
+								r.operationID = "setDeviceBrightness"
+								r.operationGroup = ""
+								r.pathPattern = "/api/device/brightness"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
 
-					if len(elem) == 0 {
-						break
-					}
-					switch elem[0] {
-					case 'a': // Prefix: "ave"
+					case 'n': // Prefix: "name"
 
-						if l := len("ave"); len(elem) >= l && elem[0:l] == "ave" {
+						if l := len("name"); len(elem) >= l && elem[0:l] == "name" {
 							elem = elem[l:]
 						} else {
 							break
@@ -417,11 +1486,11 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							// Leaf node.
 							switch method {
 							case "POST":
-								r.name = SaveAnimationOperation
-								r.summary = "Save animation to database"
-								r.operationID = "saveAnimation"
+								r.name = SetDeviceNameOperation
+								r.summary = "Rename device"
+								r.operationID = "setDeviceName"
 								r.operationGroup = ""
-								r.pathPattern = "/api/animation/save"
+								r.pathPattern = "/api/device/name"
 								r.args = args
 								r.count = 0
 								return r, true
@@ -430,9 +1499,9 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							}
 						}
 
-					case 't': // Prefix: "t"
+					case 'p': // Prefix: "p"
 
-						if l := len("t"); len(elem) >= l && elem[0:l] == "t" {
+						if l := len("p"); len(elem) >= l && elem[0:l] == "p" {
 							elem = elem[l:]
 						} else {
 							break
@@ -442,9 +1511,34 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 							break
 						}
 						switch elem[0] {
-						case 'a': // Prefix: "art"
+						case 'i': // Prefix: "ing"
 
-							if l := len("art"); len(elem) >= l && elem[0:l] == "art" {
+							if l := len("ing"); len(elem) >= l && elem[0:l] == "ing" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = PingDeviceOperation
+									r.summary = "Check device reachability"
+									r.operationID = "pingDevice"
+									r.operationGroup = ""
+									r.pathPattern = "/api/device/ping"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'o': // Prefix: "ower"
+
+							if l := len("ower"); len(elem) >= l && elem[0:l] == "ower" {
 								elem = elem[l:]
 							} else {
 								break
@@ -454,11 +1548,11 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 								// Leaf node.
 								switch method {
 								case "POST":
-									r.name = StartAnimationOperation
-									r.summary = "Start animation playback on device"
-									r.operationID = "startAnimation"
+									r.name = SetDevicePowerOperation
+									r.summary = "Switch device on or off"
+									r.operationID = "setDevicePower"
 									r.operationGroup = ""
-									r.pathPattern = "/api/animation/start"
+									r.pathPattern = "/api/device/power"
 									r.args = args
 									r.count = 0
 									return r, true
@@ -467,9 +1561,23 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 								}
 							}
 
-						case 'o': // Prefix: "op"
+						}
 
-							if l := len("op"); len(elem) >= l && elem[0:l] == "op" {
+					case 's': // Prefix: "stat"
+
+						if l := len("stat"); len(elem) >= l && elem[0:l] == "stat" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'e': // Prefix: "e"
+
+							if l := len("e"); len(elem) >= l && elem[0:l] == "e" {
 								elem = elem[l:]
 							} else {
 								break
@@ -479,11 +1587,36 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 								// Leaf node.
 								switch method {
 								case "POST":
-									r.name = StopAnimationOperation
-									r.summary = "Stop animation playback on device"
-									r.operationID = "stopAnimation"
+									r.name = ApplyDeviceStateOperation
+									r.summary = "Apply device state"
+									r.operationID = "applyDeviceState"
 									r.operationGroup = ""
-									r.pathPattern = "/api/animation/stop"
+									r.pathPattern = "/api/device/state"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+
+						case 'u': // Prefix: "us"
+
+							if l := len("us"); len(elem) >= l && elem[0:l] == "us" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch method {
+								case "GET":
+									r.name = GetDeviceStatusOperation
+									r.summary = "Get device status"
+									r.operationID = "getDeviceStatus"
+									r.operationGroup = ""
+									r.pathPattern = "/api/device/status"
 									r.args = args
 									r.count = 0
 									return r, true
@@ -494,77 +1627,67 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 
 						}
 
-					}
+					case 'w': // Prefix: "white-balance"
 
-					elem = origElem
-				}
-				// Param: "id"
-				// Leaf parameter, slashes are prohibited
-				idx := strings.IndexByte(elem, '/')
-				if idx >= 0 {
-					break
-				}
-				args[0] = elem
-				elem = ""
+						if l := len("white-balance"); len(elem) >= l && elem[0:l] == "white-balance" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetWhiteBalanceOperation
+								r.summary = "Get device white balance"
+								r.operationID = "getWhiteBalance"
+								r.operationGroup = ""
+								r.pathPattern = "/api/device/white-balance"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "PUT":
+								r.name = SetWhiteBalanceOperation
+								r.summary = "Set device white balance"
+								r.operationID = "setWhiteBalance"
+								r.operationGroup = ""
+								r.pathPattern = "/api/device/white-balance"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
 
-				if len(elem) == 0 {
-					// Leaf node.
-					switch method {
-					case "DELETE":
-						r.name = DeleteAnimationOperation
-						r.summary = "Delete a saved animation"
-						r.operationID = "deleteAnimation"
-						r.operationGroup = ""
-						r.pathPattern = "/api/animation/{id}"
-						r.args = args
-						r.count = 1
-						return r, true
-					case "GET":
-						r.name = GetAnimationOperation
-						r.summary = "Get a specific saved animation"
-						r.operationID = "getAnimation"
-						r.operationGroup = ""
-						r.pathPattern = "/api/animation/{id}"
-						r.args = args
-						r.count = 1
-						return r, true
-					case "PUT":
-						r.name = UpdateAnimationOperation
-						r.summary = "Update an existing saved animation"
-						r.operationID = "updateAnimation"
-						r.operationGroup = ""
-						r.pathPattern = "/api/animation/{id}"
-						r.args = args
-						r.count = 1
-						return r, true
-					default:
-						return
 					}
-				}
 
-			case 'd': // Prefix: "devices"
+				case 's': // Prefix: "s"
 
-				if l := len("devices"); len(elem) >= l && elem[0:l] == "devices" {
-					elem = elem[l:]
-				} else {
-					break
-				}
+					if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+						elem = elem[l:]
+					} else {
+						break
+					}
 
-				if len(elem) == 0 {
-					// Leaf node.
-					switch method {
-					case "GET":
-						r.name = GetDevicesOperation
-						r.summary = "Discover Yeelight CubeLite devices"
-						r.operationID = "getDevices"
-						r.operationGroup = ""
-						r.pathPattern = "/api/devices"
-						r.args = args
-						r.count = 0
-						return r, true
-					default:
-						return
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetDevicesOperation
+							r.summary = "Discover Yeelight CubeLite devices"
+							r.operationID = "getDevices"
+							r.operationGroup = ""
+							r.pathPattern = "/api/devices"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
 					}
+
 				}
 
 			}
@@ -3,11 +3,181 @@
 package api
 
 import (
+	"io"
 	"time"
+
+	"github.com/go-faster/errors"
 )
 
 type AnimationFrame []RGBPixel
 
+type ApplyDeviceStateBadRequest Error
+
+func (*ApplyDeviceStateBadRequest) applyDeviceStateRes() {}
+
+type ApplyDeviceStateInternalServerError Error
+
+func (*ApplyDeviceStateInternalServerError) applyDeviceStateRes() {}
+
+// Ref: #/components/schemas/ApplyDeviceStateRequest
+type ApplyDeviceStateRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// Desired power state.
+	Power OptBool `json:"power"`
+	// Desired brightness in percent.
+	Brightness OptInt      `json:"brightness"`
+	Color      OptRGBPixel `json:"color"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *ApplyDeviceStateRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// GetPower returns the value of Power.
+func (s *ApplyDeviceStateRequest) GetPower() OptBool {
+	return s.Power
+}
+
+// GetBrightness returns the value of Brightness.
+func (s *ApplyDeviceStateRequest) GetBrightness() OptInt {
+	return s.Brightness
+}
+
+// GetColor returns the value of Color.
+func (s *ApplyDeviceStateRequest) GetColor() OptRGBPixel {
+	return s.Color
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *ApplyDeviceStateRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetPower sets the value of Power.
+func (s *ApplyDeviceStateRequest) SetPower(val OptBool) {
+	s.Power = val
+}
+
+// SetBrightness sets the value of Brightness.
+func (s *ApplyDeviceStateRequest) SetBrightness(val OptInt) {
+	s.Brightness = val
+}
+
+// SetColor sets the value of Color.
+func (s *ApplyDeviceStateRequest) SetColor(val OptRGBPixel) {
+	s.Color = val
+}
+
+// Ref: #/components/schemas/ApplyDeviceStateResponse
+type ApplyDeviceStateResponse struct {
+	// Success message.
+	Message string `json:"message"`
+	// Number of commands sent to the device.
+	CommandCount int `json:"command_count"`
+}
+
+// GetMessage returns the value of Message.
+func (s *ApplyDeviceStateResponse) GetMessage() string {
+	return s.Message
+}
+
+// GetCommandCount returns the value of CommandCount.
+func (s *ApplyDeviceStateResponse) GetCommandCount() int {
+	return s.CommandCount
+}
+
+// SetMessage sets the value of Message.
+func (s *ApplyDeviceStateResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetCommandCount sets the value of CommandCount.
+func (s *ApplyDeviceStateResponse) SetCommandCount(val int) {
+	s.CommandCount = val
+}
+
+func (*ApplyDeviceStateResponse) applyDeviceStateRes() {}
+
+// Ref: #/components/schemas/ColorCapabilities
+type ColorCapabilities struct {
+	// Whether the device accepts RGB colors.
+	Rgb bool `json:"rgb"`
+	// Whether the device accepts color temperatures.
+	Ct bool `json:"ct"`
+	// Whether the device accepts hue and saturation.
+	Hsv bool `json:"hsv"`
+	// Lowest supported color temperature in Kelvin, 0 if unsupported.
+	MinCt int `json:"min_ct"`
+	// Highest supported color temperature in Kelvin, 0 if unsupported.
+	MaxCt int `json:"max_ct"`
+	// Whether min_ct and max_ct are the known range of the device's model. When false they are the
+	// protocol's default 1700–6500, which the device may not fully support; devices cannot be asked
+	// for their range.
+	CtRangeKnown bool `json:"ct_range_known"`
+}
+
+// GetRgb returns the value of Rgb.
+func (s *ColorCapabilities) GetRgb() bool {
+	return s.Rgb
+}
+
+// GetCt returns the value of Ct.
+func (s *ColorCapabilities) GetCt() bool {
+	return s.Ct
+}
+
+// GetHsv returns the value of Hsv.
+func (s *ColorCapabilities) GetHsv() bool {
+	return s.Hsv
+}
+
+// GetMinCt returns the value of MinCt.
+func (s *ColorCapabilities) GetMinCt() int {
+	return s.MinCt
+}
+
+// GetMaxCt returns the value of MaxCt.
+func (s *ColorCapabilities) GetMaxCt() int {
+	return s.MaxCt
+}
+
+// GetCtRangeKnown returns the value of CtRangeKnown.
+func (s *ColorCapabilities) GetCtRangeKnown() bool {
+	return s.CtRangeKnown
+}
+
+// SetRgb sets the value of Rgb.
+func (s *ColorCapabilities) SetRgb(val bool) {
+	s.Rgb = val
+}
+
+// SetCt sets the value of Ct.
+func (s *ColorCapabilities) SetCt(val bool) {
+	s.Ct = val
+}
+
+// SetHsv sets the value of Hsv.
+func (s *ColorCapabilities) SetHsv(val bool) {
+	s.Hsv = val
+}
+
+// SetMinCt sets the value of MinCt.
+func (s *ColorCapabilities) SetMinCt(val int) {
+	s.MinCt = val
+}
+
+// SetMaxCt sets the value of MaxCt.
+func (s *ColorCapabilities) SetMaxCt(val int) {
+	s.MaxCt = val
+}
+
+// SetCtRangeKnown sets the value of CtRangeKnown.
+func (s *ColorCapabilities) SetCtRangeKnown(val bool) {
+	s.CtRangeKnown = val
+}
+
 type DeleteAnimationInternalServerError Error
 
 func (*DeleteAnimationInternalServerError) deleteAnimationRes() {}
@@ -34,6 +204,53 @@ func (s *DeleteAnimationResponse) SetMessage(val string) {
 
 func (*DeleteAnimationResponse) deleteAnimationRes() {}
 
+// Ref: #/components/schemas/DeleteAnimationsRequest
+type DeleteAnimationsRequest struct {
+	// UUIDs of the animations to delete.
+	Ids []string `json:"ids"`
+}
+
+// GetIds returns the value of Ids.
+func (s *DeleteAnimationsRequest) GetIds() []string {
+	return s.Ids
+}
+
+// SetIds sets the value of Ids.
+func (s *DeleteAnimationsRequest) SetIds(val []string) {
+	s.Ids = val
+}
+
+// Ref: #/components/schemas/DeleteAnimationsResponse
+type DeleteAnimationsResponse struct {
+	// Success message.
+	Message string `json:"message"`
+	// Number of animations deleted.
+	DeletedCount int `json:"deleted_count"`
+}
+
+// GetMessage returns the value of Message.
+func (s *DeleteAnimationsResponse) GetMessage() string {
+	return s.Message
+}
+
+// GetDeletedCount returns the value of DeletedCount.
+func (s *DeleteAnimationsResponse) GetDeletedCount() int {
+	return s.DeletedCount
+}
+
+// SetMessage sets the value of Message.
+func (s *DeleteAnimationsResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetDeletedCount sets the value of DeletedCount.
+func (s *DeleteAnimationsResponse) SetDeletedCount(val int) {
+	s.DeletedCount = val
+}
+
+func (*DeleteAnimationsResponse) deleteAnimationsRes()       {}
+func (*DeleteAnimationsResponse) deleteDeviceAnimationsRes() {}
+
 // Ref: #/components/schemas/Device
 type Device struct {
 	// Unique device identifier.
@@ -42,6 +259,13 @@ type Device struct {
 	Name string `json:"name"`
 	// Device location in format yeelight://IP:PORT.
 	Location string `json:"location"`
+	// Set when another device at a different location reports the same ID.
+	IDConflict bool `json:"id_conflict"`
+	// Whether the device answered the latest discovery. Devices seen recently but not answering now are
+	// listed as offline.
+	Online OptBool `json:"online"`
+	// When the device last answered discovery.
+	LastSeen OptDateTime `json:"last_seen"`
 }
 
 // GetID returns the value of ID.
@@ -59,6 +283,21 @@ func (s *Device) GetLocation() string {
 	return s.Location
 }
 
+// GetIDConflict returns the value of IDConflict.
+func (s *Device) GetIDConflict() bool {
+	return s.IDConflict
+}
+
+// GetOnline returns the value of Online.
+func (s *Device) GetOnline() OptBool {
+	return s.Online
+}
+
+// GetLastSeen returns the value of LastSeen.
+func (s *Device) GetLastSeen() OptDateTime {
+	return s.LastSeen
+}
+
 // SetID sets the value of ID.
 func (s *Device) SetID(val string) {
 	s.ID = val
@@ -74,6 +313,158 @@ func (s *Device) SetLocation(val string) {
 	s.Location = val
 }
 
+// SetIDConflict sets the value of IDConflict.
+func (s *Device) SetIDConflict(val bool) {
+	s.IDConflict = val
+}
+
+// SetOnline sets the value of Online.
+func (s *Device) SetOnline(val OptBool) {
+	s.Online = val
+}
+
+// SetLastSeen sets the value of LastSeen.
+func (s *Device) SetLastSeen(val OptDateTime) {
+	s.LastSeen = val
+}
+
+// Ref: #/components/schemas/DeviceStatusResponse
+type DeviceStatusResponse struct {
+	// Whether the device is on.
+	Power bool `json:"power"`
+	// Brightness in percent.
+	Brightness int `json:"brightness"`
+	// Active color mode (1 - RGB, 2 - color temperature, 3 - HSV).
+	ColorMode    int               `json:"color_mode"`
+	Capabilities ColorCapabilities `json:"capabilities"`
+	// Whether an animation is playing on the device. Previews are not reported.
+	AnimationRunning bool `json:"animation_running"`
+	// Whether the running animation is paused because the device was switched off.
+	AnimationPausedForPower bool `json:"animation_paused_for_power"`
+	// Whether the running animation was paused with the pause endpoint.
+	AnimationPaused OptBool `json:"animation_paused"`
+	// Frame rate actually delivered by the running animation, for diagnostics.
+	AnimationFps OptFloat64 `json:"animation_fps"`
+	// Frame rate the adaptive throttle currently targets for the running animation.
+	AnimationAdaptiveFps OptFloat64 `json:"animation_adaptive_fps"`
+}
+
+// GetPower returns the value of Power.
+func (s *DeviceStatusResponse) GetPower() bool {
+	return s.Power
+}
+
+// GetBrightness returns the value of Brightness.
+func (s *DeviceStatusResponse) GetBrightness() int {
+	return s.Brightness
+}
+
+// GetColorMode returns the value of ColorMode.
+func (s *DeviceStatusResponse) GetColorMode() int {
+	return s.ColorMode
+}
+
+// GetCapabilities returns the value of Capabilities.
+func (s *DeviceStatusResponse) GetCapabilities() ColorCapabilities {
+	return s.Capabilities
+}
+
+// GetAnimationRunning returns the value of AnimationRunning.
+func (s *DeviceStatusResponse) GetAnimationRunning() bool {
+	return s.AnimationRunning
+}
+
+// GetAnimationPausedForPower returns the value of AnimationPausedForPower.
+func (s *DeviceStatusResponse) GetAnimationPausedForPower() bool {
+	return s.AnimationPausedForPower
+}
+
+// GetAnimationPaused returns the value of AnimationPaused.
+func (s *DeviceStatusResponse) GetAnimationPaused() OptBool {
+	return s.AnimationPaused
+}
+
+// GetAnimationFps returns the value of AnimationFps.
+func (s *DeviceStatusResponse) GetAnimationFps() OptFloat64 {
+	return s.AnimationFps
+}
+
+// GetAnimationAdaptiveFps returns the value of AnimationAdaptiveFps.
+func (s *DeviceStatusResponse) GetAnimationAdaptiveFps() OptFloat64 {
+	return s.AnimationAdaptiveFps
+}
+
+// SetPower sets the value of Power.
+func (s *DeviceStatusResponse) SetPower(val bool) {
+	s.Power = val
+}
+
+// SetBrightness sets the value of Brightness.
+func (s *DeviceStatusResponse) SetBrightness(val int) {
+	s.Brightness = val
+}
+
+// SetColorMode sets the value of ColorMode.
+func (s *DeviceStatusResponse) SetColorMode(val int) {
+	s.ColorMode = val
+}
+
+// SetCapabilities sets the value of Capabilities.
+func (s *DeviceStatusResponse) SetCapabilities(val ColorCapabilities) {
+	s.Capabilities = val
+}
+
+// SetAnimationRunning sets the value of AnimationRunning.
+func (s *DeviceStatusResponse) SetAnimationRunning(val bool) {
+	s.AnimationRunning = val
+}
+
+// SetAnimationPausedForPower sets the value of AnimationPausedForPower.
+func (s *DeviceStatusResponse) SetAnimationPausedForPower(val bool) {
+	s.AnimationPausedForPower = val
+}
+
+// SetAnimationPaused sets the value of AnimationPaused.
+func (s *DeviceStatusResponse) SetAnimationPaused(val OptBool) {
+	s.AnimationPaused = val
+}
+
+// SetAnimationFps sets the value of AnimationFps.
+func (s *DeviceStatusResponse) SetAnimationFps(val OptFloat64) {
+	s.AnimationFps = val
+}
+
+// SetAnimationAdaptiveFps sets the value of AnimationAdaptiveFps.
+func (s *DeviceStatusResponse) SetAnimationAdaptiveFps(val OptFloat64) {
+	s.AnimationAdaptiveFps = val
+}
+
+func (*DeviceStatusResponse) getDeviceStatusRes() {}
+
+type DuplicateAnimationInternalServerError Error
+
+func (*DuplicateAnimationInternalServerError) duplicateAnimationRes() {}
+
+type DuplicateAnimationNotFound Error
+
+func (*DuplicateAnimationNotFound) duplicateAnimationRes() {}
+
+// Ref: #/components/schemas/DuplicateAnimationRequest
+type DuplicateAnimationRequest struct {
+	// Name for the copy. Defaults to the original name followed by " copy".
+	Name OptString `json:"name"`
+}
+
+// GetName returns the value of Name.
+func (s *DuplicateAnimationRequest) GetName() OptString {
+	return s.Name
+}
+
+// SetName sets the value of Name.
+func (s *DuplicateAnimationRequest) SetName(val OptString) {
+	s.Name = val
+}
+
 // Ref: #/components/schemas/Error
 type Error struct {
 	// Error message.
@@ -90,8 +481,36 @@ func (s *Error) SetError(val string) {
 	s.Error = val
 }
 
-func (*Error) getDevicesRes()     {}
-func (*Error) listAnimationsRes() {}
+func (*Error) deleteAnimationsRes()       {}
+func (*Error) deleteDeviceAnimationsRes() {}
+func (*Error) getDeviceStatusRes()        {}
+func (*Error) getDevicesRes()             {}
+func (*Error) getWhiteBalanceRes()        {}
+func (*Error) listAnimationsRes()         {}
+
+type ExportAnimationGifInternalServerError Error
+
+func (*ExportAnimationGifInternalServerError) exportAnimationGifRes() {}
+
+type ExportAnimationGifNotFound Error
+
+func (*ExportAnimationGifNotFound) exportAnimationGifRes() {}
+
+type ExportAnimationGifOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportAnimationGifOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportAnimationGifOK) exportAnimationGifRes() {}
 
 type GetAnimationInternalServerError Error
 
@@ -118,6 +537,30 @@ func (s *GetAnimationResponse) SetAnimation(val SavedAnimation) {
 
 func (*GetAnimationResponse) getAnimationRes() {}
 
+type GetAnimationThumbnailInternalServerError Error
+
+func (*GetAnimationThumbnailInternalServerError) getAnimationThumbnailRes() {}
+
+type GetAnimationThumbnailNotFound Error
+
+func (*GetAnimationThumbnailNotFound) getAnimationThumbnailRes() {}
+
+type GetAnimationThumbnailOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetAnimationThumbnailOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*GetAnimationThumbnailOK) getAnimationThumbnailRes() {}
+
 type GetDevicesOK struct {
 	Devices []Device `json:"devices"`
 }
@@ -134,6 +577,109 @@ func (s *GetDevicesOK) SetDevices(val []Device) {
 
 func (*GetDevicesOK) getDevicesRes() {}
 
+type ImportGifAnimationBadRequest Error
+
+func (*ImportGifAnimationBadRequest) importGifAnimationRes() {}
+
+type ImportGifAnimationInternalServerError Error
+
+func (*ImportGifAnimationInternalServerError) importGifAnimationRes() {}
+
+type ImportGifAnimationReq struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportGifAnimationReq) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+type ImportImageAnimationBadRequest Error
+
+func (*ImportImageAnimationBadRequest) importImageAnimationRes() {}
+
+type ImportImageAnimationInternalServerError Error
+
+func (*ImportImageAnimationInternalServerError) importImageAnimationRes() {}
+
+type ImportImageAnimationReqImageJpeg struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportImageAnimationReqImageJpeg) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportImageAnimationReqImageJpeg) importImageAnimationReq() {}
+
+type ImportImageAnimationReqImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportImageAnimationReqImagePNG) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportImageAnimationReqImagePNG) importImageAnimationReq() {}
+
+type ListAnimationsOrder string
+
+const (
+	ListAnimationsOrderAsc  ListAnimationsOrder = "asc"
+	ListAnimationsOrderDesc ListAnimationsOrder = "desc"
+)
+
+// AllValues returns all ListAnimationsOrder values.
+func (ListAnimationsOrder) AllValues() []ListAnimationsOrder {
+	return []ListAnimationsOrder{
+		ListAnimationsOrderAsc,
+		ListAnimationsOrderDesc,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ListAnimationsOrder) MarshalText() ([]byte, error) {
+	switch s {
+	case ListAnimationsOrderAsc:
+		return []byte(s), nil
+	case ListAnimationsOrderDesc:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ListAnimationsOrder) UnmarshalText(data []byte) error {
+	switch ListAnimationsOrder(data) {
+	case ListAnimationsOrderAsc:
+		*s = ListAnimationsOrderAsc
+		return nil
+	case ListAnimationsOrderDesc:
+		*s = ListAnimationsOrderDesc
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Ref: #/components/schemas/ListAnimationsResponse
 type ListAnimationsResponse struct {
 	// List of saved animations for the device, ordered by updated_at descending.
@@ -152,72 +698,801 @@ func (s *ListAnimationsResponse) SetAnimations(val []SavedAnimation) {
 
 func (*ListAnimationsResponse) listAnimationsRes() {}
 
-// Ref: #/components/schemas/RGBPixel
-type RGBPixel struct {
-	// Red component (0-255).
-	R int32 `json:"r"`
-	// Green component (0-255).
-	G int32 `json:"g"`
-	// Blue component (0-255).
-	B int32 `json:"b"`
+type ListAnimationsSort string
+
+const (
+	ListAnimationsSortName      ListAnimationsSort = "name"
+	ListAnimationsSortCreatedAt ListAnimationsSort = "created_at"
+	ListAnimationsSortUpdatedAt ListAnimationsSort = "updated_at"
+)
+
+// AllValues returns all ListAnimationsSort values.
+func (ListAnimationsSort) AllValues() []ListAnimationsSort {
+	return []ListAnimationsSort{
+		ListAnimationsSortName,
+		ListAnimationsSortCreatedAt,
+		ListAnimationsSortUpdatedAt,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ListAnimationsSort) MarshalText() ([]byte, error) {
+	switch s {
+	case ListAnimationsSortName:
+		return []byte(s), nil
+	case ListAnimationsSortCreatedAt:
+		return []byte(s), nil
+	case ListAnimationsSortUpdatedAt:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ListAnimationsSort) UnmarshalText(data []byte) error {
+	switch ListAnimationsSort(data) {
+	case ListAnimationsSortName:
+		*s = ListAnimationsSortName
+		return nil
+	case ListAnimationsSortCreatedAt:
+		*s = ListAnimationsSortCreatedAt
+		return nil
+	case ListAnimationsSortUpdatedAt:
+		*s = ListAnimationsSortUpdatedAt
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetR returns the value of R.
-func (s *RGBPixel) GetR() int32 {
-	return s.R
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
 }
 
-// GetG returns the value of G.
-func (s *RGBPixel) GetG() int32 {
-	return s.G
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
 }
 
-// GetB returns the value of B.
-func (s *RGBPixel) GetB() int32 {
-	return s.B
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetR sets the value of R.
-func (s *RGBPixel) SetR(val int32) {
-	s.R = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetG sets the value of G.
-func (s *RGBPixel) SetG(val int32) {
-	s.G = val
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetB sets the value of B.
-func (s *RGBPixel) SetB(val int32) {
-	s.B = val
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
 }
 
-type SaveAnimationBadRequest Error
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
 
-func (*SaveAnimationBadRequest) saveAnimationRes() {}
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
-type SaveAnimationInternalServerError Error
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
 
-func (*SaveAnimationInternalServerError) saveAnimationRes() {}
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
 
-// Ref: #/components/schemas/SaveAnimationRequest
-type SaveAnimationRequest struct {
-	// Unique device identifier.
-	DeviceID string `json:"device_id"`
-	// Name for the animation.
-	Name string `json:"name"`
-	// Array of animation frames to save.
-	Frames []AnimationFrame `json:"frames"`
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetDeviceID returns the value of DeviceID.
-func (s *SaveAnimationRequest) GetDeviceID() string {
-	return s.DeviceID
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetName returns the value of Name.
-func (s *SaveAnimationRequest) GetName() string {
-	return s.Name
+// NewOptDuplicateAnimationRequest returns new OptDuplicateAnimationRequest with value set to v.
+func NewOptDuplicateAnimationRequest(v DuplicateAnimationRequest) OptDuplicateAnimationRequest {
+	return OptDuplicateAnimationRequest{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDuplicateAnimationRequest is optional DuplicateAnimationRequest.
+type OptDuplicateAnimationRequest struct {
+	Value DuplicateAnimationRequest
+	Set   bool
+}
+
+// IsSet returns true if OptDuplicateAnimationRequest was set.
+func (o OptDuplicateAnimationRequest) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDuplicateAnimationRequest) Reset() {
+	var v DuplicateAnimationRequest
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDuplicateAnimationRequest) SetTo(v DuplicateAnimationRequest) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDuplicateAnimationRequest) Get() (v DuplicateAnimationRequest, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDuplicateAnimationRequest) Or(d DuplicateAnimationRequest) DuplicateAnimationRequest {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptListAnimationsOrder returns new OptListAnimationsOrder with value set to v.
+func NewOptListAnimationsOrder(v ListAnimationsOrder) OptListAnimationsOrder {
+	return OptListAnimationsOrder{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptListAnimationsOrder is optional ListAnimationsOrder.
+type OptListAnimationsOrder struct {
+	Value ListAnimationsOrder
+	Set   bool
+}
+
+// IsSet returns true if OptListAnimationsOrder was set.
+func (o OptListAnimationsOrder) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptListAnimationsOrder) Reset() {
+	var v ListAnimationsOrder
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptListAnimationsOrder) SetTo(v ListAnimationsOrder) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptListAnimationsOrder) Get() (v ListAnimationsOrder, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptListAnimationsOrder) Or(d ListAnimationsOrder) ListAnimationsOrder {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptListAnimationsSort returns new OptListAnimationsSort with value set to v.
+func NewOptListAnimationsSort(v ListAnimationsSort) OptListAnimationsSort {
+	return OptListAnimationsSort{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptListAnimationsSort is optional ListAnimationsSort.
+type OptListAnimationsSort struct {
+	Value ListAnimationsSort
+	Set   bool
+}
+
+// IsSet returns true if OptListAnimationsSort was set.
+func (o OptListAnimationsSort) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptListAnimationsSort) Reset() {
+	var v ListAnimationsSort
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptListAnimationsSort) SetTo(v ListAnimationsSort) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptListAnimationsSort) Get() (v ListAnimationsSort, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptListAnimationsSort) Or(d ListAnimationsSort) ListAnimationsSort {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRGBPixel returns new OptRGBPixel with value set to v.
+func NewOptRGBPixel(v RGBPixel) OptRGBPixel {
+	return OptRGBPixel{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRGBPixel is optional RGBPixel.
+type OptRGBPixel struct {
+	Value RGBPixel
+	Set   bool
+}
+
+// IsSet returns true if OptRGBPixel was set.
+func (o OptRGBPixel) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRGBPixel) Reset() {
+	var v RGBPixel
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRGBPixel) SetTo(v RGBPixel) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRGBPixel) Get() (v RGBPixel, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRGBPixel) Or(d RGBPixel) RGBPixel {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptStartAnimationRequestPlaybackMode returns new OptStartAnimationRequestPlaybackMode with value set to v.
+func NewOptStartAnimationRequestPlaybackMode(v StartAnimationRequestPlaybackMode) OptStartAnimationRequestPlaybackMode {
+	return OptStartAnimationRequestPlaybackMode{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptStartAnimationRequestPlaybackMode is optional StartAnimationRequestPlaybackMode.
+type OptStartAnimationRequestPlaybackMode struct {
+	Value StartAnimationRequestPlaybackMode
+	Set   bool
+}
+
+// IsSet returns true if OptStartAnimationRequestPlaybackMode was set.
+func (o OptStartAnimationRequestPlaybackMode) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptStartAnimationRequestPlaybackMode) Reset() {
+	var v StartAnimationRequestPlaybackMode
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptStartAnimationRequestPlaybackMode) SetTo(v StartAnimationRequestPlaybackMode) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptStartAnimationRequestPlaybackMode) Get() (v StartAnimationRequestPlaybackMode, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptStartAnimationRequestPlaybackMode) Or(d StartAnimationRequestPlaybackMode) StartAnimationRequestPlaybackMode {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+type PauseAnimationInternalServerError Error
+
+func (*PauseAnimationInternalServerError) pauseAnimationRes() {}
+
+type PauseAnimationNotFound Error
+
+func (*PauseAnimationNotFound) pauseAnimationRes() {}
+
+// Ref: #/components/schemas/PauseAnimationRequest
+type PauseAnimationRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *PauseAnimationRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *PauseAnimationRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// Ref: #/components/schemas/PauseAnimationResponse
+type PauseAnimationResponse struct {
+	// Success message.
+	Message string `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *PauseAnimationResponse) GetMessage() string {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *PauseAnimationResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*PauseAnimationResponse) pauseAnimationRes() {}
+
+// Ref: #/components/schemas/PingDeviceResponse
+type PingDeviceResponse struct {
+	// Whether the device accepted a connection.
+	Reachable bool `json:"reachable"`
+	// When reachability was last verified.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// GetReachable returns the value of Reachable.
+func (s *PingDeviceResponse) GetReachable() bool {
+	return s.Reachable
+}
+
+// GetCheckedAt returns the value of CheckedAt.
+func (s *PingDeviceResponse) GetCheckedAt() time.Time {
+	return s.CheckedAt
+}
+
+// SetReachable sets the value of Reachable.
+func (s *PingDeviceResponse) SetReachable(val bool) {
+	s.Reachable = val
+}
+
+// SetCheckedAt sets the value of CheckedAt.
+func (s *PingDeviceResponse) SetCheckedAt(val time.Time) {
+	s.CheckedAt = val
+}
+
+// Ref: #/components/schemas/RGBPixel
+type RGBPixel struct {
+	// Red component (0-255).
+	R int32 `json:"r"`
+	// Green component (0-255).
+	G int32 `json:"g"`
+	// Blue component (0-255).
+	B int32 `json:"b"`
+}
+
+// GetR returns the value of R.
+func (s *RGBPixel) GetR() int32 {
+	return s.R
+}
+
+// GetG returns the value of G.
+func (s *RGBPixel) GetG() int32 {
+	return s.G
+}
+
+// GetB returns the value of B.
+func (s *RGBPixel) GetB() int32 {
+	return s.B
+}
+
+// SetR sets the value of R.
+func (s *RGBPixel) SetR(val int32) {
+	s.R = val
+}
+
+// SetG sets the value of G.
+func (s *RGBPixel) SetG(val int32) {
+	s.G = val
+}
+
+// SetB sets the value of B.
+func (s *RGBPixel) SetB(val int32) {
+	s.B = val
+}
+
+type RedoAnimationConflict Error
+
+func (*RedoAnimationConflict) redoAnimationRes() {}
+
+type RedoAnimationInternalServerError Error
+
+func (*RedoAnimationInternalServerError) redoAnimationRes() {}
+
+type RedoAnimationNotFound Error
+
+func (*RedoAnimationNotFound) redoAnimationRes() {}
+
+type ResumeAnimationInternalServerError Error
+
+func (*ResumeAnimationInternalServerError) resumeAnimationRes() {}
+
+type ResumeAnimationNotFound Error
+
+func (*ResumeAnimationNotFound) resumeAnimationRes() {}
+
+// Ref: #/components/schemas/ResumeAnimationRequest
+type ResumeAnimationRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *ResumeAnimationRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *ResumeAnimationRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// Ref: #/components/schemas/ResumeAnimationResponse
+type ResumeAnimationResponse struct {
+	// Success message.
+	Message string `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *ResumeAnimationResponse) GetMessage() string {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *ResumeAnimationResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*ResumeAnimationResponse) resumeAnimationRes() {}
+
+// Ref: #/components/schemas/RunningAnimation
+type RunningAnimation struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// Index of the frame currently shown.
+	FrameIndex int `json:"frame_index"`
+	// Number of frames in the animation.
+	FrameCount int `json:"frame_count"`
+	// Whether playback is paused.
+	Paused bool `json:"paused"`
+	// Whether playback is suspended because the device is switched off.
+	PausedForPower bool `json:"paused_for_power"`
+	// Whether the animation is an ephemeral preview.
+	Preview bool `json:"preview"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *RunningAnimation) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// GetFrameIndex returns the value of FrameIndex.
+func (s *RunningAnimation) GetFrameIndex() int {
+	return s.FrameIndex
+}
+
+// GetFrameCount returns the value of FrameCount.
+func (s *RunningAnimation) GetFrameCount() int {
+	return s.FrameCount
+}
+
+// GetPaused returns the value of Paused.
+func (s *RunningAnimation) GetPaused() bool {
+	return s.Paused
+}
+
+// GetPausedForPower returns the value of PausedForPower.
+func (s *RunningAnimation) GetPausedForPower() bool {
+	return s.PausedForPower
+}
+
+// GetPreview returns the value of Preview.
+func (s *RunningAnimation) GetPreview() bool {
+	return s.Preview
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *RunningAnimation) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetFrameIndex sets the value of FrameIndex.
+func (s *RunningAnimation) SetFrameIndex(val int) {
+	s.FrameIndex = val
+}
+
+// SetFrameCount sets the value of FrameCount.
+func (s *RunningAnimation) SetFrameCount(val int) {
+	s.FrameCount = val
+}
+
+// SetPaused sets the value of Paused.
+func (s *RunningAnimation) SetPaused(val bool) {
+	s.Paused = val
+}
+
+// SetPausedForPower sets the value of PausedForPower.
+func (s *RunningAnimation) SetPausedForPower(val bool) {
+	s.PausedForPower = val
+}
+
+// SetPreview sets the value of Preview.
+func (s *RunningAnimation) SetPreview(val bool) {
+	s.Preview = val
+}
+
+// Ref: #/components/schemas/RunningAnimationsResponse
+type RunningAnimationsResponse struct {
+	Animations []RunningAnimation `json:"animations"`
+}
+
+// GetAnimations returns the value of Animations.
+func (s *RunningAnimationsResponse) GetAnimations() []RunningAnimation {
+	return s.Animations
+}
+
+// SetAnimations sets the value of Animations.
+func (s *RunningAnimationsResponse) SetAnimations(val []RunningAnimation) {
+	s.Animations = val
+}
+
+type SaveAnimationBadRequest Error
+
+func (*SaveAnimationBadRequest) saveAnimationRes() {}
+
+type SaveAnimationInternalServerError Error
+
+func (*SaveAnimationInternalServerError) saveAnimationRes() {}
+
+// Ref: #/components/schemas/SaveAnimationRequest
+type SaveAnimationRequest struct {
+	// Unique device identifier.
+	DeviceID string `json:"device_id"`
+	// Name for the animation.
+	Name string `json:"name"`
+	// Array of animation frames to save. At most 1000 frames.
+	Frames []AnimationFrame `json:"frames"`
+	// How long each frame is shown, in milliseconds.
+	FrameDurationMs OptInt `json:"frame_duration_ms"`
+	// Optional per-frame durations in milliseconds, one per frame. Overrides frame_duration_ms.
+	FrameDurationsMs []int `json:"frame_durations_ms"`
+	// Labels for organizing animations. Tags are trimmed and lowercased.
+	Tags []string `json:"tags"`
+}
+
+// GetDeviceID returns the value of DeviceID.
+func (s *SaveAnimationRequest) GetDeviceID() string {
+	return s.DeviceID
+}
+
+// GetName returns the value of Name.
+func (s *SaveAnimationRequest) GetName() string {
+	return s.Name
 }
 
 // GetFrames returns the value of Frames.
@@ -225,142 +1500,449 @@ func (s *SaveAnimationRequest) GetFrames() []AnimationFrame {
 	return s.Frames
 }
 
+// GetFrameDurationMs returns the value of FrameDurationMs.
+func (s *SaveAnimationRequest) GetFrameDurationMs() OptInt {
+	return s.FrameDurationMs
+}
+
+// GetFrameDurationsMs returns the value of FrameDurationsMs.
+func (s *SaveAnimationRequest) GetFrameDurationsMs() []int {
+	return s.FrameDurationsMs
+}
+
+// GetTags returns the value of Tags.
+func (s *SaveAnimationRequest) GetTags() []string {
+	return s.Tags
+}
+
 // SetDeviceID sets the value of DeviceID.
 func (s *SaveAnimationRequest) SetDeviceID(val string) {
 	s.DeviceID = val
 }
 
 // SetName sets the value of Name.
-func (s *SaveAnimationRequest) SetName(val string) {
+func (s *SaveAnimationRequest) SetName(val string) {
+	s.Name = val
+}
+
+// SetFrames sets the value of Frames.
+func (s *SaveAnimationRequest) SetFrames(val []AnimationFrame) {
+	s.Frames = val
+}
+
+// SetFrameDurationMs sets the value of FrameDurationMs.
+func (s *SaveAnimationRequest) SetFrameDurationMs(val OptInt) {
+	s.FrameDurationMs = val
+}
+
+// SetFrameDurationsMs sets the value of FrameDurationsMs.
+func (s *SaveAnimationRequest) SetFrameDurationsMs(val []int) {
+	s.FrameDurationsMs = val
+}
+
+// SetTags sets the value of Tags.
+func (s *SaveAnimationRequest) SetTags(val []string) {
+	s.Tags = val
+}
+
+// Ref: #/components/schemas/SaveAnimationResponse
+type SaveAnimationResponse struct {
+	// UUID of the newly saved animation.
+	ID string `json:"id"`
+	// Success message.
+	Message   string         `json:"message"`
+	Animation SavedAnimation `json:"animation"`
+}
+
+// GetID returns the value of ID.
+func (s *SaveAnimationResponse) GetID() string {
+	return s.ID
+}
+
+// GetMessage returns the value of Message.
+func (s *SaveAnimationResponse) GetMessage() string {
+	return s.Message
+}
+
+// GetAnimation returns the value of Animation.
+func (s *SaveAnimationResponse) GetAnimation() SavedAnimation {
+	return s.Animation
+}
+
+// SetID sets the value of ID.
+func (s *SaveAnimationResponse) SetID(val string) {
+	s.ID = val
+}
+
+// SetMessage sets the value of Message.
+func (s *SaveAnimationResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetAnimation sets the value of Animation.
+func (s *SaveAnimationResponse) SetAnimation(val SavedAnimation) {
+	s.Animation = val
+}
+
+func (*SaveAnimationResponse) duplicateAnimationRes()   {}
+func (*SaveAnimationResponse) importGifAnimationRes()   {}
+func (*SaveAnimationResponse) importImageAnimationRes() {}
+func (*SaveAnimationResponse) saveAnimationRes()        {}
+
+// Ref: #/components/schemas/SavedAnimation
+type SavedAnimation struct {
+	// Unique animation identifier.
+	ID string `json:"id"`
+	// Unique device identifier this animation is saved for.
+	DeviceID string `json:"device_id"`
+	// User-provided name for the animation.
+	Name string `json:"name"`
+	// Array of animation frames.
+	Frames []AnimationFrame `json:"frames"`
+	// How long each frame is shown, in milliseconds.
+	FrameDurationMs int `json:"frame_duration_ms"`
+	// Per-frame durations in milliseconds, one per frame, when the animation has them. Overrides
+	// frame_duration_ms.
+	FrameDurationsMs []int `json:"frame_durations_ms"`
+	// Labels for organizing animations, lowercased.
+	Tags []string `json:"tags"`
+	// Timestamp when animation was created.
+	CreatedAt time.Time `json:"created_at"`
+	// Timestamp when animation was last updated.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetID returns the value of ID.
+func (s *SavedAnimation) GetID() string {
+	return s.ID
+}
+
+// GetDeviceID returns the value of DeviceID.
+func (s *SavedAnimation) GetDeviceID() string {
+	return s.DeviceID
+}
+
+// GetName returns the value of Name.
+func (s *SavedAnimation) GetName() string {
+	return s.Name
+}
+
+// GetFrames returns the value of Frames.
+func (s *SavedAnimation) GetFrames() []AnimationFrame {
+	return s.Frames
+}
+
+// GetFrameDurationMs returns the value of FrameDurationMs.
+func (s *SavedAnimation) GetFrameDurationMs() int {
+	return s.FrameDurationMs
+}
+
+// GetFrameDurationsMs returns the value of FrameDurationsMs.
+func (s *SavedAnimation) GetFrameDurationsMs() []int {
+	return s.FrameDurationsMs
+}
+
+// GetTags returns the value of Tags.
+func (s *SavedAnimation) GetTags() []string {
+	return s.Tags
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *SavedAnimation) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *SavedAnimation) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetID sets the value of ID.
+func (s *SavedAnimation) SetID(val string) {
+	s.ID = val
+}
+
+// SetDeviceID sets the value of DeviceID.
+func (s *SavedAnimation) SetDeviceID(val string) {
+	s.DeviceID = val
+}
+
+// SetName sets the value of Name.
+func (s *SavedAnimation) SetName(val string) {
+	s.Name = val
+}
+
+// SetFrames sets the value of Frames.
+func (s *SavedAnimation) SetFrames(val []AnimationFrame) {
+	s.Frames = val
+}
+
+// SetFrameDurationMs sets the value of FrameDurationMs.
+func (s *SavedAnimation) SetFrameDurationMs(val int) {
+	s.FrameDurationMs = val
+}
+
+// SetFrameDurationsMs sets the value of FrameDurationsMs.
+func (s *SavedAnimation) SetFrameDurationsMs(val []int) {
+	s.FrameDurationsMs = val
+}
+
+// SetTags sets the value of Tags.
+func (s *SavedAnimation) SetTags(val []string) {
+	s.Tags = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *SavedAnimation) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *SavedAnimation) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+type SetDeviceBrightnessBadRequest Error
+
+func (*SetDeviceBrightnessBadRequest) setDeviceBrightnessRes() {}
+
+type SetDeviceBrightnessInternalServerError Error
+
+func (*SetDeviceBrightnessInternalServerError) setDeviceBrightnessRes() {}
+
+// Ref: #/components/schemas/SetDeviceBrightnessRequest
+type SetDeviceBrightnessRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// Brightness in percent.
+	Brightness int `json:"brightness"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *SetDeviceBrightnessRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// GetBrightness returns the value of Brightness.
+func (s *SetDeviceBrightnessRequest) GetBrightness() int {
+	return s.Brightness
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *SetDeviceBrightnessRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetBrightness sets the value of Brightness.
+func (s *SetDeviceBrightnessRequest) SetBrightness(val int) {
+	s.Brightness = val
+}
+
+// Ref: #/components/schemas/SetDeviceBrightnessResponse
+type SetDeviceBrightnessResponse struct {
+	// Success message.
+	Message string `json:"message"`
+	// Brightness applied to the device.
+	Brightness int `json:"brightness"`
+}
+
+// GetMessage returns the value of Message.
+func (s *SetDeviceBrightnessResponse) GetMessage() string {
+	return s.Message
+}
+
+// GetBrightness returns the value of Brightness.
+func (s *SetDeviceBrightnessResponse) GetBrightness() int {
+	return s.Brightness
+}
+
+// SetMessage sets the value of Message.
+func (s *SetDeviceBrightnessResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetBrightness sets the value of Brightness.
+func (s *SetDeviceBrightnessResponse) SetBrightness(val int) {
+	s.Brightness = val
+}
+
+func (*SetDeviceBrightnessResponse) setDeviceBrightnessRes() {}
+
+type SetDeviceNameBadRequest Error
+
+func (*SetDeviceNameBadRequest) setDeviceNameRes() {}
+
+type SetDeviceNameInternalServerError Error
+
+func (*SetDeviceNameInternalServerError) setDeviceNameRes() {}
+
+// Ref: #/components/schemas/SetDeviceNameRequest
+type SetDeviceNameRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// New device name.
+	Name string `json:"name"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *SetDeviceNameRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// GetName returns the value of Name.
+func (s *SetDeviceNameRequest) GetName() string {
+	return s.Name
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *SetDeviceNameRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetName sets the value of Name.
+func (s *SetDeviceNameRequest) SetName(val string) {
 	s.Name = val
 }
 
-// SetFrames sets the value of Frames.
-func (s *SaveAnimationRequest) SetFrames(val []AnimationFrame) {
-	s.Frames = val
-}
-
-// Ref: #/components/schemas/SaveAnimationResponse
-type SaveAnimationResponse struct {
-	// UUID of the newly saved animation.
-	ID string `json:"id"`
+// Ref: #/components/schemas/SetDeviceNameResponse
+type SetDeviceNameResponse struct {
 	// Success message.
-	Message   string         `json:"message"`
-	Animation SavedAnimation `json:"animation"`
-}
-
-// GetID returns the value of ID.
-func (s *SaveAnimationResponse) GetID() string {
-	return s.ID
+	Message string `json:"message"`
+	// Name reported by the device after the change.
+	Name string `json:"name"`
 }
 
 // GetMessage returns the value of Message.
-func (s *SaveAnimationResponse) GetMessage() string {
+func (s *SetDeviceNameResponse) GetMessage() string {
 	return s.Message
 }
 
-// GetAnimation returns the value of Animation.
-func (s *SaveAnimationResponse) GetAnimation() SavedAnimation {
-	return s.Animation
-}
-
-// SetID sets the value of ID.
-func (s *SaveAnimationResponse) SetID(val string) {
-	s.ID = val
+// GetName returns the value of Name.
+func (s *SetDeviceNameResponse) GetName() string {
+	return s.Name
 }
 
 // SetMessage sets the value of Message.
-func (s *SaveAnimationResponse) SetMessage(val string) {
+func (s *SetDeviceNameResponse) SetMessage(val string) {
 	s.Message = val
 }
 
-// SetAnimation sets the value of Animation.
-func (s *SaveAnimationResponse) SetAnimation(val SavedAnimation) {
-	s.Animation = val
+// SetName sets the value of Name.
+func (s *SetDeviceNameResponse) SetName(val string) {
+	s.Name = val
 }
 
-func (*SaveAnimationResponse) saveAnimationRes() {}
+func (*SetDeviceNameResponse) setDeviceNameRes() {}
 
-// Ref: #/components/schemas/SavedAnimation
-type SavedAnimation struct {
-	// Unique animation identifier.
-	ID string `json:"id"`
-	// Unique device identifier this animation is saved for.
-	DeviceID string `json:"device_id"`
-	// User-provided name for the animation.
-	Name string `json:"name"`
-	// Array of animation frames.
-	Frames []AnimationFrame `json:"frames"`
-	// Timestamp when animation was created.
-	CreatedAt time.Time `json:"created_at"`
-	// Timestamp when animation was last updated.
-	UpdatedAt time.Time `json:"updated_at"`
+type SetDevicePowerBadRequest Error
+
+func (*SetDevicePowerBadRequest) setDevicePowerRes() {}
+
+type SetDevicePowerInternalServerError Error
+
+func (*SetDevicePowerInternalServerError) setDevicePowerRes() {}
+
+// Ref: #/components/schemas/SetDevicePowerRequest
+type SetDevicePowerRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// Desired power state.
+	Power bool `json:"power"`
 }
 
-// GetID returns the value of ID.
-func (s *SavedAnimation) GetID() string {
-	return s.ID
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *SetDevicePowerRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
 }
 
-// GetDeviceID returns the value of DeviceID.
-func (s *SavedAnimation) GetDeviceID() string {
-	return s.DeviceID
+// GetPower returns the value of Power.
+func (s *SetDevicePowerRequest) GetPower() bool {
+	return s.Power
 }
 
-// GetName returns the value of Name.
-func (s *SavedAnimation) GetName() string {
-	return s.Name
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *SetDevicePowerRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
 }
 
-// GetFrames returns the value of Frames.
-func (s *SavedAnimation) GetFrames() []AnimationFrame {
-	return s.Frames
+// SetPower sets the value of Power.
+func (s *SetDevicePowerRequest) SetPower(val bool) {
+	s.Power = val
 }
 
-// GetCreatedAt returns the value of CreatedAt.
-func (s *SavedAnimation) GetCreatedAt() time.Time {
-	return s.CreatedAt
+// Ref: #/components/schemas/SetDevicePowerResponse
+type SetDevicePowerResponse struct {
+	// Success message.
+	Message string `json:"message"`
+	// Power state applied to the device.
+	Power bool `json:"power"`
 }
 
-// GetUpdatedAt returns the value of UpdatedAt.
-func (s *SavedAnimation) GetUpdatedAt() time.Time {
-	return s.UpdatedAt
+// GetMessage returns the value of Message.
+func (s *SetDevicePowerResponse) GetMessage() string {
+	return s.Message
 }
 
-// SetID sets the value of ID.
-func (s *SavedAnimation) SetID(val string) {
-	s.ID = val
+// GetPower returns the value of Power.
+func (s *SetDevicePowerResponse) GetPower() bool {
+	return s.Power
 }
 
-// SetDeviceID sets the value of DeviceID.
-func (s *SavedAnimation) SetDeviceID(val string) {
-	s.DeviceID = val
+// SetMessage sets the value of Message.
+func (s *SetDevicePowerResponse) SetMessage(val string) {
+	s.Message = val
 }
 
-// SetName sets the value of Name.
-func (s *SavedAnimation) SetName(val string) {
-	s.Name = val
+// SetPower sets the value of Power.
+func (s *SetDevicePowerResponse) SetPower(val bool) {
+	s.Power = val
 }
 
-// SetFrames sets the value of Frames.
-func (s *SavedAnimation) SetFrames(val []AnimationFrame) {
-	s.Frames = val
+func (*SetDevicePowerResponse) setDevicePowerRes() {}
+
+type SetWhiteBalanceBadRequest Error
+
+func (*SetWhiteBalanceBadRequest) setWhiteBalanceRes() {}
+
+type SetWhiteBalanceInternalServerError Error
+
+func (*SetWhiteBalanceInternalServerError) setWhiteBalanceRes() {}
+
+// Ref: #/components/schemas/SetWhiteBalanceRequest
+type SetWhiteBalanceRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string       `json:"device_location"`
+	WhiteBalance   WhiteBalance `json:"white_balance"`
 }
 
-// SetCreatedAt sets the value of CreatedAt.
-func (s *SavedAnimation) SetCreatedAt(val time.Time) {
-	s.CreatedAt = val
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *SetWhiteBalanceRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
 }
 
-// SetUpdatedAt sets the value of UpdatedAt.
-func (s *SavedAnimation) SetUpdatedAt(val time.Time) {
-	s.UpdatedAt = val
+// GetWhiteBalance returns the value of WhiteBalance.
+func (s *SetWhiteBalanceRequest) GetWhiteBalance() WhiteBalance {
+	return s.WhiteBalance
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *SetWhiteBalanceRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetWhiteBalance sets the value of WhiteBalance.
+func (s *SetWhiteBalanceRequest) SetWhiteBalance(val WhiteBalance) {
+	s.WhiteBalance = val
 }
 
 type StartAnimationBadRequest Error
 
 func (*StartAnimationBadRequest) startAnimationRes() {}
 
+type StartAnimationConflict Error
+
+func (*StartAnimationConflict) startAnimationRes() {}
+
 type StartAnimationInternalServerError Error
 
 func (*StartAnimationInternalServerError) startAnimationRes() {}
@@ -369,8 +1951,30 @@ func (*StartAnimationInternalServerError) startAnimationRes() {}
 type StartAnimationRequest struct {
 	// Device location in format yeelight://IP:PORT.
 	DeviceLocation string `json:"device_location"`
-	// Array of animation frames to play in sequence.
+	// Array of animation frames to play in sequence. At most 1000 frames.
 	Frames []AnimationFrame `json:"frames"`
+	// Play the frames once as an ephemeral preview instead of looping. Previews are not reported as the
+	// device's running animation and are rejected with 409 while an animation is running on the device.
+	Preview OptBool `json:"preview"`
+	// How long each frame is shown, in milliseconds. Defaults to 1000 when neither this nor fps is set.
+	// Durations shorter than one frame at the server's ANIMATION_MAX_FPS (100 ms at the default of 10)
+	// are rejected.
+	FrameDurationMs OptInt `json:"frame_duration_ms"`
+	// Frames per second to play at, as an alternative to frame_duration_ms. Setting both is an error.
+	// Rates above the server's ANIMATION_MAX_FPS (10 by default) are rejected.
+	Fps OptInt `json:"fps"`
+	// Optional per-frame durations in milliseconds, one per frame. Overrides fps and frame_duration_ms.
+	FrameDurationsMs []int `json:"frame_durations_ms"`
+	// Play the frames first to last, or first to last and back again.
+	PlaybackMode OptStartAnimationRequestPlaybackMode `json:"playback_mode"`
+	// How many passes over the frames to play before stopping. 0 loops until stopped.
+	LoopCount OptInt `json:"loop_count"`
+	// Number of intermediate frames that crossfade each frame into the next over the frame duration. 0
+	// switches frames instantly. Limited by the server's maximum frame rate.
+	TransitionSteps OptInt `json:"transition_steps"`
+	// Blank the matrix when the animation is stopped instead of leaving the last frame lit. Animations
+	// that finish by themselves keep their final frame.
+	ClearOnStop OptBool `json:"clear_on_stop"`
 }
 
 // GetDeviceLocation returns the value of DeviceLocation.
@@ -383,6 +1987,46 @@ func (s *StartAnimationRequest) GetFrames() []AnimationFrame {
 	return s.Frames
 }
 
+// GetPreview returns the value of Preview.
+func (s *StartAnimationRequest) GetPreview() OptBool {
+	return s.Preview
+}
+
+// GetFrameDurationMs returns the value of FrameDurationMs.
+func (s *StartAnimationRequest) GetFrameDurationMs() OptInt {
+	return s.FrameDurationMs
+}
+
+// GetFps returns the value of Fps.
+func (s *StartAnimationRequest) GetFps() OptInt {
+	return s.Fps
+}
+
+// GetFrameDurationsMs returns the value of FrameDurationsMs.
+func (s *StartAnimationRequest) GetFrameDurationsMs() []int {
+	return s.FrameDurationsMs
+}
+
+// GetPlaybackMode returns the value of PlaybackMode.
+func (s *StartAnimationRequest) GetPlaybackMode() OptStartAnimationRequestPlaybackMode {
+	return s.PlaybackMode
+}
+
+// GetLoopCount returns the value of LoopCount.
+func (s *StartAnimationRequest) GetLoopCount() OptInt {
+	return s.LoopCount
+}
+
+// GetTransitionSteps returns the value of TransitionSteps.
+func (s *StartAnimationRequest) GetTransitionSteps() OptInt {
+	return s.TransitionSteps
+}
+
+// GetClearOnStop returns the value of ClearOnStop.
+func (s *StartAnimationRequest) GetClearOnStop() OptBool {
+	return s.ClearOnStop
+}
+
 // SetDeviceLocation sets the value of DeviceLocation.
 func (s *StartAnimationRequest) SetDeviceLocation(val string) {
 	s.DeviceLocation = val
@@ -393,6 +2037,88 @@ func (s *StartAnimationRequest) SetFrames(val []AnimationFrame) {
 	s.Frames = val
 }
 
+// SetPreview sets the value of Preview.
+func (s *StartAnimationRequest) SetPreview(val OptBool) {
+	s.Preview = val
+}
+
+// SetFrameDurationMs sets the value of FrameDurationMs.
+func (s *StartAnimationRequest) SetFrameDurationMs(val OptInt) {
+	s.FrameDurationMs = val
+}
+
+// SetFps sets the value of Fps.
+func (s *StartAnimationRequest) SetFps(val OptInt) {
+	s.Fps = val
+}
+
+// SetFrameDurationsMs sets the value of FrameDurationsMs.
+func (s *StartAnimationRequest) SetFrameDurationsMs(val []int) {
+	s.FrameDurationsMs = val
+}
+
+// SetPlaybackMode sets the value of PlaybackMode.
+func (s *StartAnimationRequest) SetPlaybackMode(val OptStartAnimationRequestPlaybackMode) {
+	s.PlaybackMode = val
+}
+
+// SetLoopCount sets the value of LoopCount.
+func (s *StartAnimationRequest) SetLoopCount(val OptInt) {
+	s.LoopCount = val
+}
+
+// SetTransitionSteps sets the value of TransitionSteps.
+func (s *StartAnimationRequest) SetTransitionSteps(val OptInt) {
+	s.TransitionSteps = val
+}
+
+// SetClearOnStop sets the value of ClearOnStop.
+func (s *StartAnimationRequest) SetClearOnStop(val OptBool) {
+	s.ClearOnStop = val
+}
+
+// Play the frames first to last, or first to last and back again.
+type StartAnimationRequestPlaybackMode string
+
+const (
+	StartAnimationRequestPlaybackModeForward  StartAnimationRequestPlaybackMode = "forward"
+	StartAnimationRequestPlaybackModePingPong StartAnimationRequestPlaybackMode = "ping_pong"
+)
+
+// AllValues returns all StartAnimationRequestPlaybackMode values.
+func (StartAnimationRequestPlaybackMode) AllValues() []StartAnimationRequestPlaybackMode {
+	return []StartAnimationRequestPlaybackMode{
+		StartAnimationRequestPlaybackModeForward,
+		StartAnimationRequestPlaybackModePingPong,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s StartAnimationRequestPlaybackMode) MarshalText() ([]byte, error) {
+	switch s {
+	case StartAnimationRequestPlaybackModeForward:
+		return []byte(s), nil
+	case StartAnimationRequestPlaybackModePingPong:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *StartAnimationRequestPlaybackMode) UnmarshalText(data []byte) error {
+	switch StartAnimationRequestPlaybackMode(data) {
+	case StartAnimationRequestPlaybackModeForward:
+		*s = StartAnimationRequestPlaybackModeForward
+		return nil
+	case StartAnimationRequestPlaybackModePingPong:
+		*s = StartAnimationRequestPlaybackModePingPong
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Ref: #/components/schemas/StartAnimationResponse
 type StartAnimationResponse struct {
 	// Success message.
@@ -423,6 +2149,113 @@ func (s *StartAnimationResponse) SetFrameCount(val int) {
 
 func (*StartAnimationResponse) startAnimationRes() {}
 
+type StartPlaylistBadRequest Error
+
+func (*StartPlaylistBadRequest) startPlaylistRes() {}
+
+type StartPlaylistInternalServerError Error
+
+func (*StartPlaylistInternalServerError) startPlaylistRes() {}
+
+type StartPlaylistNotFound Error
+
+func (*StartPlaylistNotFound) startPlaylistRes() {}
+
+// Ref: #/components/schemas/StartPlaylistRequest
+type StartPlaylistRequest struct {
+	// Device location in format yeelight://IP:PORT.
+	DeviceLocation string `json:"device_location"`
+	// Saved animation IDs to play, in order. Mutually exclusive with tag.
+	AnimationIds []string `json:"animation_ids"`
+	// Play all of the device_id's animations with this tag, oldest first. Mutually exclusive with
+	// animation_ids.
+	Tag OptString `json:"tag"`
+	// ID of the device whose saved animations the tag selects from. Required with tag.
+	DeviceID OptString `json:"device_id"`
+	// How long each animation plays before switching to the next, in milliseconds.
+	ItemDurationMs int `json:"item_duration_ms"`
+}
+
+// GetDeviceLocation returns the value of DeviceLocation.
+func (s *StartPlaylistRequest) GetDeviceLocation() string {
+	return s.DeviceLocation
+}
+
+// GetAnimationIds returns the value of AnimationIds.
+func (s *StartPlaylistRequest) GetAnimationIds() []string {
+	return s.AnimationIds
+}
+
+// GetTag returns the value of Tag.
+func (s *StartPlaylistRequest) GetTag() OptString {
+	return s.Tag
+}
+
+// GetDeviceID returns the value of DeviceID.
+func (s *StartPlaylistRequest) GetDeviceID() OptString {
+	return s.DeviceID
+}
+
+// GetItemDurationMs returns the value of ItemDurationMs.
+func (s *StartPlaylistRequest) GetItemDurationMs() int {
+	return s.ItemDurationMs
+}
+
+// SetDeviceLocation sets the value of DeviceLocation.
+func (s *StartPlaylistRequest) SetDeviceLocation(val string) {
+	s.DeviceLocation = val
+}
+
+// SetAnimationIds sets the value of AnimationIds.
+func (s *StartPlaylistRequest) SetAnimationIds(val []string) {
+	s.AnimationIds = val
+}
+
+// SetTag sets the value of Tag.
+func (s *StartPlaylistRequest) SetTag(val OptString) {
+	s.Tag = val
+}
+
+// SetDeviceID sets the value of DeviceID.
+func (s *StartPlaylistRequest) SetDeviceID(val OptString) {
+	s.DeviceID = val
+}
+
+// SetItemDurationMs sets the value of ItemDurationMs.
+func (s *StartPlaylistRequest) SetItemDurationMs(val int) {
+	s.ItemDurationMs = val
+}
+
+// Ref: #/components/schemas/StartPlaylistResponse
+type StartPlaylistResponse struct {
+	// Success message.
+	Message string `json:"message"`
+	// Number of animations in the playlist.
+	ItemCount int `json:"item_count"`
+}
+
+// GetMessage returns the value of Message.
+func (s *StartPlaylistResponse) GetMessage() string {
+	return s.Message
+}
+
+// GetItemCount returns the value of ItemCount.
+func (s *StartPlaylistResponse) GetItemCount() int {
+	return s.ItemCount
+}
+
+// SetMessage sets the value of Message.
+func (s *StartPlaylistResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+// SetItemCount sets the value of ItemCount.
+func (s *StartPlaylistResponse) SetItemCount(val int) {
+	s.ItemCount = val
+}
+
+func (*StartPlaylistResponse) startPlaylistRes() {}
+
 type StopAnimationBadRequest Error
 
 func (*StopAnimationBadRequest) stopAnimationRes() {}
@@ -465,6 +2298,18 @@ func (s *StopAnimationResponse) SetMessage(val string) {
 
 func (*StopAnimationResponse) stopAnimationRes() {}
 
+type UndoAnimationConflict Error
+
+func (*UndoAnimationConflict) undoAnimationRes() {}
+
+type UndoAnimationInternalServerError Error
+
+func (*UndoAnimationInternalServerError) undoAnimationRes() {}
+
+type UndoAnimationNotFound Error
+
+func (*UndoAnimationNotFound) undoAnimationRes() {}
+
 type UpdateAnimationBadRequest Error
 
 func (*UpdateAnimationBadRequest) updateAnimationRes() {}
@@ -481,8 +2326,16 @@ func (*UpdateAnimationNotFound) updateAnimationRes() {}
 type UpdateAnimationRequest struct {
 	// Updated name for the animation.
 	Name string `json:"name"`
-	// Updated animation frames.
+	// Updated animation frames. At most 1000 frames.
 	Frames []AnimationFrame `json:"frames"`
+	// New uniform frame duration in milliseconds. Omit to keep the current one.
+	FrameDurationMs OptInt `json:"frame_duration_ms"`
+	// Replacement per-frame durations in milliseconds, one per frame. Pass an empty array to drop them.
+	// Omit to keep the current ones; the update is then rejected if they no longer match the number of
+	// frames.
+	FrameDurationsMs []int `json:"frame_durations_ms"`
+	// Replacement tags. Omit to keep the current tags.
+	Tags []string `json:"tags"`
 }
 
 // GetName returns the value of Name.
@@ -495,6 +2348,21 @@ func (s *UpdateAnimationRequest) GetFrames() []AnimationFrame {
 	return s.Frames
 }
 
+// GetFrameDurationMs returns the value of FrameDurationMs.
+func (s *UpdateAnimationRequest) GetFrameDurationMs() OptInt {
+	return s.FrameDurationMs
+}
+
+// GetFrameDurationsMs returns the value of FrameDurationsMs.
+func (s *UpdateAnimationRequest) GetFrameDurationsMs() []int {
+	return s.FrameDurationsMs
+}
+
+// GetTags returns the value of Tags.
+func (s *UpdateAnimationRequest) GetTags() []string {
+	return s.Tags
+}
+
 // SetName sets the value of Name.
 func (s *UpdateAnimationRequest) SetName(val string) {
 	s.Name = val
@@ -505,6 +2373,21 @@ func (s *UpdateAnimationRequest) SetFrames(val []AnimationFrame) {
 	s.Frames = val
 }
 
+// SetFrameDurationMs sets the value of FrameDurationMs.
+func (s *UpdateAnimationRequest) SetFrameDurationMs(val OptInt) {
+	s.FrameDurationMs = val
+}
+
+// SetFrameDurationsMs sets the value of FrameDurationsMs.
+func (s *UpdateAnimationRequest) SetFrameDurationsMs(val []int) {
+	s.FrameDurationsMs = val
+}
+
+// SetTags sets the value of Tags.
+func (s *UpdateAnimationRequest) SetTags(val []string) {
+	s.Tags = val
+}
+
 // Ref: #/components/schemas/UpdateAnimationResponse
 type UpdateAnimationResponse struct {
 	// Success message.
@@ -532,4 +2415,49 @@ func (s *UpdateAnimationResponse) SetAnimation(val SavedAnimation) {
 	s.Animation = val
 }
 
+func (*UpdateAnimationResponse) redoAnimationRes()   {}
+func (*UpdateAnimationResponse) undoAnimationRes()   {}
 func (*UpdateAnimationResponse) updateAnimationRes() {}
+
+// Ref: #/components/schemas/WhiteBalance
+type WhiteBalance struct {
+	// Red channel gain, 1 leaves the channel unchanged.
+	R float64 `json:"r"`
+	// Green channel gain, 1 leaves the channel unchanged.
+	G float64 `json:"g"`
+	// Blue channel gain, 1 leaves the channel unchanged.
+	B float64 `json:"b"`
+}
+
+// GetR returns the value of R.
+func (s *WhiteBalance) GetR() float64 {
+	return s.R
+}
+
+// GetG returns the value of G.
+func (s *WhiteBalance) GetG() float64 {
+	return s.G
+}
+
+// GetB returns the value of B.
+func (s *WhiteBalance) GetB() float64 {
+	return s.B
+}
+
+// SetR sets the value of R.
+func (s *WhiteBalance) SetR(val float64) {
+	s.R = val
+}
+
+// SetG sets the value of G.
+func (s *WhiteBalance) SetG(val float64) {
+	s.G = val
+}
+
+// SetB sets the value of B.
+func (s *WhiteBalance) SetB(val float64) {
+	s.B = val
+}
+
+func (*WhiteBalance) getWhiteBalanceRes() {}
+func (*WhiteBalance) setWhiteBalanceRes() {}
@@ -8,36 +8,168 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// ApplyDeviceState implements applyDeviceState operation.
+	//
+	// Applies power, brightness and color to the device in one request. All given settings are sent over
+	// a single connection; power on is applied first and power off last.
+	//
+	// POST /api/device/state
+	ApplyDeviceState(ctx context.Context, req *ApplyDeviceStateRequest) (ApplyDeviceStateRes, error)
 	// DeleteAnimation implements deleteAnimation operation.
 	//
 	// Permanently removes a saved animation from the database.
 	//
 	// DELETE /api/animation/{id}
 	DeleteAnimation(ctx context.Context, params DeleteAnimationParams) (DeleteAnimationRes, error)
+	// DeleteAnimations implements deleteAnimations operation.
+	//
+	// Permanently removes the animations with the given IDs. IDs that do not exist are ignored.
+	//
+	// POST /api/animation/delete
+	DeleteAnimations(ctx context.Context, req *DeleteAnimationsRequest) (DeleteAnimationsRes, error)
+	// DeleteDeviceAnimations implements deleteDeviceAnimations operation.
+	//
+	// Permanently removes every animation saved for the specified device, including their undo history.
+	//
+	// DELETE /api/animation/list/{device_id}
+	DeleteDeviceAnimations(ctx context.Context, params DeleteDeviceAnimationsParams) (DeleteDeviceAnimationsRes, error)
+	// DuplicateAnimation implements duplicateAnimation operation.
+	//
+	// Saves a copy of the animation with a new ID, including its frames, timing and tags. The copy
+	// starts without undo history.
+	//
+	// POST /api/animation/{id}/duplicate
+	DuplicateAnimation(ctx context.Context, req OptDuplicateAnimationRequest, params DuplicateAnimationParams) (DuplicateAnimationRes, error)
+	// ExportAnimationGif implements exportAnimationGif operation.
+	//
+	// Renders the animation as a looping GIF with every matrix pixel scaled up to a block of pixels.
+	// Frames keep their per-frame durations when the animation has them, otherwise they play at the
+	// requested fps or the animation's frame duration.
+	//
+	// GET /api/animation/{id}/gif
+	ExportAnimationGif(ctx context.Context, params ExportAnimationGifParams) (ExportAnimationGifRes, error)
 	// GetAnimation implements getAnimation operation.
 	//
 	// Retrieves a saved animation by its ID.
 	//
 	// GET /api/animation/{id}
 	GetAnimation(ctx context.Context, params GetAnimationParams) (GetAnimationRes, error)
+	// GetAnimationThumbnail implements getAnimationThumbnail operation.
+	//
+	// Renders the first frame of the animation as a PNG with every matrix pixel scaled up to a block of
+	// pixels.
+	//
+	// GET /api/animation/{id}/thumbnail
+	GetAnimationThumbnail(ctx context.Context, params GetAnimationThumbnailParams) (GetAnimationThumbnailRes, error)
+	// GetDeviceStatus implements getDeviceStatus operation.
+	//
+	// Queries the device for its current power, brightness and color mode, together with the color modes
+	// and color temperature range it supports.
+	//
+	// GET /api/device/status
+	GetDeviceStatus(ctx context.Context, params GetDeviceStatusParams) (GetDeviceStatusRes, error)
 	// GetDevices implements getDevices operation.
 	//
-	// Performs live SSDP discovery and returns currently available devices on the local network.
+	// Performs live SSDP discovery and returns the devices that answered, followed by devices seen
+	// within the last day that did not answer, marked offline.
 	//
 	// GET /api/devices
 	GetDevices(ctx context.Context) (GetDevicesRes, error)
+	// GetWhiteBalance implements getWhiteBalance operation.
+	//
+	// Returns the per-channel gains applied to every frame sent to the device. Devices without
+	// calibration report gains of 1.
+	//
+	// GET /api/device/white-balance
+	GetWhiteBalance(ctx context.Context, params GetWhiteBalanceParams) (GetWhiteBalanceRes, error)
+	// ImportGifAnimation implements importGifAnimation operation.
+	//
+	// Decodes the uploaded GIF, fits every frame to the 20x5 matrix by cropping to its aspect ratio and
+	// nearest-neighbour scaling, and saves the result like saveAnimation. Frame delays from the GIF
+	// become per-frame durations.
+	//
+	// POST /api/animation/import/gif
+	ImportGifAnimation(ctx context.Context, req ImportGifAnimationReq, params ImportGifAnimationParams) (ImportGifAnimationRes, error)
+	// ImportImageAnimation implements importImageAnimation operation.
+	//
+	// Decodes the uploaded image, fits it to the 20x5 matrix by cropping to its aspect ratio and
+	// averaging, and saves it as a single-frame animation. Transparent areas become black.
+	//
+	// POST /api/animation/import/image
+	ImportImageAnimation(ctx context.Context, req ImportImageAnimationReq, params ImportImageAnimationParams) (ImportImageAnimationRes, error)
 	// ListAnimations implements listAnimations operation.
 	//
-	// Returns all saved animations for the specified device, ordered by most recently updated.
+	// Returns the saved animations for the specified device, by default ordered by most recently updated.
+	//  Results can be filtered by name, tag and creation time and sorted by name or date.
 	//
 	// GET /api/animation/list/{device_id}
 	ListAnimations(ctx context.Context, params ListAnimationsParams) (ListAnimationsRes, error)
+	// ListRunningAnimations implements listRunningAnimations operation.
+	//
+	// Reports every device with an animation playing, including previews, with its playback position.
+	// Useful to find stale animations still driving a device.
+	//
+	// GET /api/animation/running
+	ListRunningAnimations(ctx context.Context) (*RunningAnimationsResponse, error)
+	// PauseAnimation implements pauseAnimation operation.
+	//
+	// Freezes the animation running on the specified device on its current frame, keeping its position
+	// so it can be resumed.
+	//
+	// POST /api/animation/pause
+	PauseAnimation(ctx context.Context, req *PauseAnimationRequest) (PauseAnimationRes, error)
+	// PingDevice implements pingDevice operation.
+	//
+	// Reports whether the device accepts connections. Results are cached for a short time and refreshed
+	// in the background, so frequent polling does not dial the device on every request.
+	//
+	// GET /api/device/ping
+	PingDevice(ctx context.Context, params PingDeviceParams) (*PingDeviceResponse, error)
+	// RedoAnimation implements redoAnimation operation.
+	//
+	// Reapplies the last update reverted by undo. Redo history is discarded when the animation is
+	// updated.
+	//
+	// POST /api/animation/{id}/redo
+	RedoAnimation(ctx context.Context, params RedoAnimationParams) (RedoAnimationRes, error)
+	// ResumeAnimation implements resumeAnimation operation.
+	//
+	// Continues a paused animation on the specified device with the frame after the one it was paused on.
+	//
+	// POST /api/animation/resume
+	ResumeAnimation(ctx context.Context, req *ResumeAnimationRequest) (ResumeAnimationRes, error)
 	// SaveAnimation implements saveAnimation operation.
 	//
 	// Saves the current animation frames to the database with a name. Stored per device.
 	//
 	// POST /api/animation/save
 	SaveAnimation(ctx context.Context, req *SaveAnimationRequest) (SaveAnimationRes, error)
+	// SetDeviceBrightness implements setDeviceBrightness operation.
+	//
+	// Sets the device brightness in percent.
+	//
+	// POST /api/device/brightness
+	SetDeviceBrightness(ctx context.Context, req *SetDeviceBrightnessRequest) (SetDeviceBrightnessRes, error)
+	// SetDeviceName implements setDeviceName operation.
+	//
+	// Sets the device name and reads it back to confirm the device stored it, since some firmware
+	// acknowledges the change without applying it.
+	//
+	// POST /api/device/name
+	SetDeviceName(ctx context.Context, req *SetDeviceNameRequest) (SetDeviceNameRes, error)
+	// SetDevicePower implements setDevicePower operation.
+	//
+	// Sets the device power state. Unlike toggling, the result does not depend on the current state.
+	//
+	// POST /api/device/power
+	SetDevicePower(ctx context.Context, req *SetDevicePowerRequest) (SetDevicePowerRes, error)
+	// SetWhiteBalance implements setWhiteBalance operation.
+	//
+	// Persists per-channel gains that correct the device's color cast. The gains apply to all subsequent
+	// frames, including those of a running animation.
+	//
+	// PUT /api/device/white-balance
+	SetWhiteBalance(ctx context.Context, req *SetWhiteBalanceRequest) (SetWhiteBalanceRes, error)
 	// StartAnimation implements startAnimation operation.
 	//
 	// Starts playing an animation loop on the specified device. Only one animation can run per device at
@@ -45,12 +177,29 @@ type Handler interface {
 	//
 	// POST /api/animation/start
 	StartAnimation(ctx context.Context, req *StartAnimationRequest) (StartAnimationRes, error)
+	// StartPlaylist implements startPlaylist operation.
+	//
+	// Plays saved animations one after another on the specified device, switching to the next one after
+	// the per-item duration and looping back to the first after the last. The animations are given
+	// either as a list of IDs or as a tag, which selects all of a device's animations with that tag in
+	// the order they were created. Replaces any animation currently running on the device and is stopped
+	// like a single animation.
+	//
+	// POST /api/animation/playlist
+	StartPlaylist(ctx context.Context, req *StartPlaylistRequest) (StartPlaylistRes, error)
 	// StopAnimation implements stopAnimation operation.
 	//
 	// Stops the currently running animation on the specified device. No-op if no animation is running.
 	//
 	// POST /api/animation/stop
 	StopAnimation(ctx context.Context, req *StopAnimationRequest) (StopAnimationRes, error)
+	// UndoAnimation implements undoAnimation operation.
+	//
+	// Restores the animation to its state before the last update. Up to 20 previous states are kept per
+	// animation.
+	//
+	// POST /api/animation/{id}/undo
+	UndoAnimation(ctx context.Context, params UndoAnimationParams) (UndoAnimationRes, error)
 	// UpdateAnimation implements updateAnimation operation.
 	//
 	// Overwrites an existing animation's name and frames.
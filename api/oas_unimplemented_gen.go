@@ -13,6 +13,16 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// ApplyDeviceState implements applyDeviceState operation.
+//
+// Applies power, brightness and color to the device in one request. All given settings are sent over
+// a single connection; power on is applied first and power off last.
+//
+// POST /api/device/state
+func (UnimplementedHandler) ApplyDeviceState(ctx context.Context, req *ApplyDeviceStateRequest) (r ApplyDeviceStateRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // DeleteAnimation implements deleteAnimation operation.
 //
 // Permanently removes a saved animation from the database.
@@ -22,6 +32,45 @@ func (UnimplementedHandler) DeleteAnimation(ctx context.Context, params DeleteAn
 	return r, ht.ErrNotImplemented
 }
 
+// DeleteAnimations implements deleteAnimations operation.
+//
+// Permanently removes the animations with the given IDs. IDs that do not exist are ignored.
+//
+// POST /api/animation/delete
+func (UnimplementedHandler) DeleteAnimations(ctx context.Context, req *DeleteAnimationsRequest) (r DeleteAnimationsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteDeviceAnimations implements deleteDeviceAnimations operation.
+//
+// Permanently removes every animation saved for the specified device, including their undo history.
+//
+// DELETE /api/animation/list/{device_id}
+func (UnimplementedHandler) DeleteDeviceAnimations(ctx context.Context, params DeleteDeviceAnimationsParams) (r DeleteDeviceAnimationsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DuplicateAnimation implements duplicateAnimation operation.
+//
+// Saves a copy of the animation with a new ID, including its frames, timing and tags. The copy
+// starts without undo history.
+//
+// POST /api/animation/{id}/duplicate
+func (UnimplementedHandler) DuplicateAnimation(ctx context.Context, req OptDuplicateAnimationRequest, params DuplicateAnimationParams) (r DuplicateAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ExportAnimationGif implements exportAnimationGif operation.
+//
+// Renders the animation as a looping GIF with every matrix pixel scaled up to a block of pixels.
+// Frames keep their per-frame durations when the animation has them, otherwise they play at the
+// requested fps or the animation's frame duration.
+//
+// GET /api/animation/{id}/gif
+func (UnimplementedHandler) ExportAnimationGif(ctx context.Context, params ExportAnimationGifParams) (r ExportAnimationGifRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetAnimation implements getAnimation operation.
 //
 // Retrieves a saved animation by its ID.
@@ -31,24 +80,127 @@ func (UnimplementedHandler) GetAnimation(ctx context.Context, params GetAnimatio
 	return r, ht.ErrNotImplemented
 }
 
+// GetAnimationThumbnail implements getAnimationThumbnail operation.
+//
+// Renders the first frame of the animation as a PNG with every matrix pixel scaled up to a block of
+// pixels.
+//
+// GET /api/animation/{id}/thumbnail
+func (UnimplementedHandler) GetAnimationThumbnail(ctx context.Context, params GetAnimationThumbnailParams) (r GetAnimationThumbnailRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetDeviceStatus implements getDeviceStatus operation.
+//
+// Queries the device for its current power, brightness and color mode, together with the color modes
+// and color temperature range it supports.
+//
+// GET /api/device/status
+func (UnimplementedHandler) GetDeviceStatus(ctx context.Context, params GetDeviceStatusParams) (r GetDeviceStatusRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetDevices implements getDevices operation.
 //
-// Performs live SSDP discovery and returns currently available devices on the local network.
+// Performs live SSDP discovery and returns the devices that answered, followed by devices seen
+// within the last day that did not answer, marked offline.
 //
 // GET /api/devices
 func (UnimplementedHandler) GetDevices(ctx context.Context) (r GetDevicesRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// GetWhiteBalance implements getWhiteBalance operation.
+//
+// Returns the per-channel gains applied to every frame sent to the device. Devices without
+// calibration report gains of 1.
+//
+// GET /api/device/white-balance
+func (UnimplementedHandler) GetWhiteBalance(ctx context.Context, params GetWhiteBalanceParams) (r GetWhiteBalanceRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ImportGifAnimation implements importGifAnimation operation.
+//
+// Decodes the uploaded GIF, fits every frame to the 20x5 matrix by cropping to its aspect ratio and
+// nearest-neighbour scaling, and saves the result like saveAnimation. Frame delays from the GIF
+// become per-frame durations.
+//
+// POST /api/animation/import/gif
+func (UnimplementedHandler) ImportGifAnimation(ctx context.Context, req ImportGifAnimationReq, params ImportGifAnimationParams) (r ImportGifAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ImportImageAnimation implements importImageAnimation operation.
+//
+// Decodes the uploaded image, fits it to the 20x5 matrix by cropping to its aspect ratio and
+// averaging, and saves it as a single-frame animation. Transparent areas become black.
+//
+// POST /api/animation/import/image
+func (UnimplementedHandler) ImportImageAnimation(ctx context.Context, req ImportImageAnimationReq, params ImportImageAnimationParams) (r ImportImageAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // ListAnimations implements listAnimations operation.
 //
-// Returns all saved animations for the specified device, ordered by most recently updated.
+// Returns the saved animations for the specified device, by default ordered by most recently updated.
+//
+//	Results can be filtered by name, tag and creation time and sorted by name or date.
 //
 // GET /api/animation/list/{device_id}
 func (UnimplementedHandler) ListAnimations(ctx context.Context, params ListAnimationsParams) (r ListAnimationsRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// ListRunningAnimations implements listRunningAnimations operation.
+//
+// Reports every device with an animation playing, including previews, with its playback position.
+// Useful to find stale animations still driving a device.
+//
+// GET /api/animation/running
+func (UnimplementedHandler) ListRunningAnimations(ctx context.Context) (r *RunningAnimationsResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PauseAnimation implements pauseAnimation operation.
+//
+// Freezes the animation running on the specified device on its current frame, keeping its position
+// so it can be resumed.
+//
+// POST /api/animation/pause
+func (UnimplementedHandler) PauseAnimation(ctx context.Context, req *PauseAnimationRequest) (r PauseAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PingDevice implements pingDevice operation.
+//
+// Reports whether the device accepts connections. Results are cached for a short time and refreshed
+// in the background, so frequent polling does not dial the device on every request.
+//
+// GET /api/device/ping
+func (UnimplementedHandler) PingDevice(ctx context.Context, params PingDeviceParams) (r *PingDeviceResponse, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RedoAnimation implements redoAnimation operation.
+//
+// Reapplies the last update reverted by undo. Redo history is discarded when the animation is
+// updated.
+//
+// POST /api/animation/{id}/redo
+func (UnimplementedHandler) RedoAnimation(ctx context.Context, params RedoAnimationParams) (r RedoAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ResumeAnimation implements resumeAnimation operation.
+//
+// Continues a paused animation on the specified device with the frame after the one it was paused on.
+//
+// POST /api/animation/resume
+func (UnimplementedHandler) ResumeAnimation(ctx context.Context, req *ResumeAnimationRequest) (r ResumeAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // SaveAnimation implements saveAnimation operation.
 //
 // Saves the current animation frames to the database with a name. Stored per device.
@@ -58,6 +210,44 @@ func (UnimplementedHandler) SaveAnimation(ctx context.Context, req *SaveAnimatio
 	return r, ht.ErrNotImplemented
 }
 
+// SetDeviceBrightness implements setDeviceBrightness operation.
+//
+// Sets the device brightness in percent.
+//
+// POST /api/device/brightness
+func (UnimplementedHandler) SetDeviceBrightness(ctx context.Context, req *SetDeviceBrightnessRequest) (r SetDeviceBrightnessRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetDeviceName implements setDeviceName operation.
+//
+// Sets the device name and reads it back to confirm the device stored it, since some firmware
+// acknowledges the change without applying it.
+//
+// POST /api/device/name
+func (UnimplementedHandler) SetDeviceName(ctx context.Context, req *SetDeviceNameRequest) (r SetDeviceNameRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetDevicePower implements setDevicePower operation.
+//
+// Sets the device power state. Unlike toggling, the result does not depend on the current state.
+//
+// POST /api/device/power
+func (UnimplementedHandler) SetDevicePower(ctx context.Context, req *SetDevicePowerRequest) (r SetDevicePowerRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetWhiteBalance implements setWhiteBalance operation.
+//
+// Persists per-channel gains that correct the device's color cast. The gains apply to all subsequent
+// frames, including those of a running animation.
+//
+// PUT /api/device/white-balance
+func (UnimplementedHandler) SetWhiteBalance(ctx context.Context, req *SetWhiteBalanceRequest) (r SetWhiteBalanceRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // StartAnimation implements startAnimation operation.
 //
 // Starts playing an animation loop on the specified device. Only one animation can run per device at
@@ -68,6 +258,19 @@ func (UnimplementedHandler) StartAnimation(ctx context.Context, req *StartAnimat
 	return r, ht.ErrNotImplemented
 }
 
+// StartPlaylist implements startPlaylist operation.
+//
+// Plays saved animations one after another on the specified device, switching to the next one after
+// the per-item duration and looping back to the first after the last. The animations are given
+// either as a list of IDs or as a tag, which selects all of a device's animations with that tag in
+// the order they were created. Replaces any animation currently running on the device and is stopped
+// like a single animation.
+//
+// POST /api/animation/playlist
+func (UnimplementedHandler) StartPlaylist(ctx context.Context, req *StartPlaylistRequest) (r StartPlaylistRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // StopAnimation implements stopAnimation operation.
 //
 // Stops the currently running animation on the specified device. No-op if no animation is running.
@@ -77,6 +280,16 @@ func (UnimplementedHandler) StopAnimation(ctx context.Context, req *StopAnimatio
 	return r, ht.ErrNotImplemented
 }
 
+// UndoAnimation implements undoAnimation operation.
+//
+// Restores the animation to its state before the last update. Up to 20 previous states are kept per
+// animation.
+//
+// POST /api/animation/{id}/undo
+func (UnimplementedHandler) UndoAnimation(ctx context.Context, params UndoAnimationParams) (r UndoAnimationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // UpdateAnimation implements updateAnimation operation.
 //
 // Overwrites an existing animation's name and frames.
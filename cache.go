@@ -0,0 +1,95 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// animationLRU caches deserialized animations by ID so frequently played
+// animations skip the database and frame decoding. Every write made through
+// the server removes the entry it changed; rows changed behind the server's
+// back are served stale until evicted. Cached values are shared between
+// callers and must not be modified.
+type animationLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+	// removals counts calls to Remove, so a read that raced with a write
+	// is not cached; see Put.
+	removals uint64
+}
+
+func newAnimationLRU(capacity int) *animationLRU {
+	return &animationLRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+var animationCache = newAnimationLRU(64)
+
+// SetAnimationCacheSize replaces the animation cache with an empty one
+// holding at most size animations. A size of 0 disables caching.
+func SetAnimationCacheSize(size int) {
+	animationCache = newAnimationLRU(size)
+}
+
+// Get returns the cached animation with the given ID, along with the
+// generation to pass to Put when it is not cached and is read from the
+// database instead.
+func (c *animationLRU) Get(id string) (*SavedAnimation, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil, c.removals, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*SavedAnimation), c.removals, true
+}
+
+// Put stores anim, read from the database after Get returned generation,
+// unless an entry was removed in between, in which case anim may predate
+// that write, or the cache already holds a newer version of it.
+func (c *animationLRU) Put(anim *SavedAnimation, generation uint64) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.removals != generation {
+		return
+	}
+
+	if elem, ok := c.entries[anim.ID]; ok {
+		if elem.Value.(*SavedAnimation).UpdatedAt.After(anim.UpdatedAt) {
+			return
+		}
+		elem.Value = anim
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[anim.ID] = c.order.PushFront(anim)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*SavedAnimation).ID)
+	}
+}
+
+func (c *animationLRU) Remove(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removals++
+	if elem, ok := c.entries[id]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, id)
+	}
+}
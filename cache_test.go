@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAnimationLRU(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	version := func(id string, minutes int) *SavedAnimation {
+		return &SavedAnimation{ID: id, UpdatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	a0, a1, b, c := version("a", 0), version("a", 1), version("b", 0), version("c", 0)
+
+	tests := []struct {
+		name     string
+		capacity int
+		// put is stored in order, each with the generation Get returned
+		// for it just before.
+		put []*SavedAnimation
+		// racingRemove is removed between each Get and Put, like a write
+		// committed while the animation was read from the database.
+		racingRemove string
+		remove       []string
+		// get is looked up after the puts and removals, in order; want
+		// holds the expected results, nil for a miss.
+		get  []string
+		want []*SavedAnimation
+	}{
+		{name: "hit", capacity: 2, put: []*SavedAnimation{a0}, get: []string{"a"}, want: []*SavedAnimation{a0}},
+		{
+			name:     "older version does not replace a newer one",
+			capacity: 2,
+			put:      []*SavedAnimation{a1, a0},
+			get:      []string{"a"},
+			want:     []*SavedAnimation{a1},
+		},
+		{
+			name:     "removed misses",
+			capacity: 2,
+			put:      []*SavedAnimation{a0, b},
+			remove:   []string{"a"},
+			get:      []string{"a", "b"},
+			want:     []*SavedAnimation{nil, b},
+		},
+		{
+			name:         "read racing a write is not stored",
+			capacity:     2,
+			put:          []*SavedAnimation{a0},
+			racingRemove: "a",
+			get:          []string{"a"},
+			want:         []*SavedAnimation{nil},
+		},
+		{
+			name:     "least recently used is evicted",
+			capacity: 2,
+			put:      []*SavedAnimation{a0, b, c},
+			get:      []string{"a", "b", "c"},
+			want:     []*SavedAnimation{nil, b, c},
+		},
+		{name: "disabled", capacity: 0, put: []*SavedAnimation{a0}, get: []string{"a"}, want: []*SavedAnimation{nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newAnimationLRU(tt.capacity)
+			for _, anim := range tt.put {
+				_, generation, _ := cache.Get(anim.ID)
+				if tt.racingRemove != "" {
+					cache.Remove(tt.racingRemove)
+				}
+				cache.Put(anim, generation)
+			}
+			for _, id := range tt.remove {
+				cache.Remove(id)
+			}
+			for i, id := range tt.get {
+				if got, _, _ := cache.Get(id); got != tt.want[i] {
+					t.Errorf("Get(%s) = %+v, want %+v", id, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetAnimationCache(t *testing.T) {
+	SetAnimationCacheSize(8)
+	t.Cleanup(func() { SetAnimationCacheSize(64) })
+
+	ctx := context.Background()
+	db := newTestDB(t)
+	frames := [][]Color{solidFrame(Color{R: 255})}
+	saved, err := SaveAnimation(ctx, db, "device", "a", frames, time.Second, nil, nil)
+	if err != nil {
+		t.Fatalf("SaveAnimation() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		// change modifies the stored animation before it is read again.
+		change   func(t *testing.T)
+		wantSame bool
+		wantName string
+	}{
+		{name: "unchanged is served from the cache", change: func(*testing.T) {}, wantSame: true, wantName: "a"},
+		{
+			name: "update through the server",
+			change: func(t *testing.T) {
+				if _, err := UpdateAnimation(ctx, db, saved.ID, "b", frames, 0, nil, nil); err != nil {
+					t.Fatalf("UpdateAnimation() error = %v", err)
+				}
+			},
+			wantName: "b",
+		},
+		{
+			name: "undo through the server",
+			change: func(t *testing.T) {
+				if _, err := UndoAnimation(ctx, db, saved.ID); err != nil {
+					t.Fatalf("UndoAnimation() error = %v", err)
+				}
+			},
+			wantName: "a",
+		},
+		{
+			// The cached value is served without querying the database,
+			// so a row changed behind the server's back is not noticed.
+			name: "row changed behind the server's back",
+			change: func(t *testing.T) {
+				if _, err := db.ExecContext(
+					ctx,
+					`UPDATE saved_animations SET name = 'c', updated_at = '2099-01-01T00:00:00Z' WHERE id = ?`,
+					saved.ID,
+				); err != nil {
+					t.Fatalf("failed to update row: %v", err)
+				}
+			},
+			wantSame: true,
+			wantName: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := GetAnimation(ctx, db, saved.ID)
+			if err != nil {
+				t.Fatalf("GetAnimation() error = %v", err)
+			}
+			tt.change(t)
+			after, err := GetAnimation(ctx, db, saved.ID)
+			if err != nil {
+				t.Fatalf("GetAnimation() error = %v", err)
+			}
+
+			if (before == after) != tt.wantSame {
+				t.Errorf("second GetAnimation() served the cached value = %v, want %v", before == after, tt.wantSame)
+			}
+			if after.Name != tt.wantName {
+				t.Errorf("GetAnimation() name = %q, want %q", after.Name, tt.wantName)
+			}
+		})
+	}
+
+	if err := DeleteAnimation(ctx, db, saved.ID); err != nil {
+		t.Fatalf("DeleteAnimation() error = %v", err)
+	}
+	if _, err := GetAnimation(ctx, db, saved.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetAnimation() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
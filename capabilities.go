@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Color temperature bounds accepted by set_ct_abx according to the Yeelight
+// inter-operation spec. Individual models may support less.
+const (
+	defaultMinCT = 1700
+	defaultMaxCT = 6500
+)
+
+// modelCTRanges are the color temperature ranges of the models whose range
+// is known, by the model name they report in discovery. The protocol has no
+// command to ask a device for its range.
+var modelCTRanges = map[string][2]int{
+	"color":    {1700, 6500},
+	"color1":   {1700, 6500},
+	"strip1":   {1700, 6500},
+	"bslamp1":  {1700, 6500},
+	"ct_bulb":  {2700, 6500},
+	"ceiling1": {2700, 6500},
+	"ceiling2": {2700, 6500},
+	"ceiling3": {2700, 6500},
+	"ceiling4": {2700, 6500},
+	"lamp1":    {2700, 5000},
+}
+
+// ColorCapabilities describes which color modes a device accepts and the
+// color temperature range it supports. MinCT and MaxCT are zero when the
+// device has no color temperature mode. CTRangeKnown is set when they come
+// from the device's model; otherwise they are the protocol's 1700–6500
+// bounds, which the device may not fully support.
+type ColorCapabilities struct {
+	RGB          bool
+	CT           bool
+	HSV          bool
+	MinCT        int
+	MaxCT        int
+	CTRangeKnown bool
+}
+
+// SupportedMethods returns the methods the device advertised in its
+// discovery answer, or nil for a device that was not discovered.
+func (d *DeviceInfo) SupportedMethods() []string {
+	return strings.Fields(d.Support)
+}
+
+// Supports reports whether the device advertised method in its discovery
+// answer. It is always false for a device that was not discovered, since
+// nothing is known about its methods.
+func (d *DeviceInfo) Supports(method string) bool {
+	return slices.Contains(d.SupportedMethods(), method)
+}
+
+// ErrUnsupportedMethod is returned by commands the device does not advertise
+// instead of sending them and waiting for the device to time out.
+var ErrUnsupportedMethod = errors.New("method not supported by this device")
+
+// checkSupported returns ErrUnsupportedMethod if the device was discovered
+// and did not advertise method. Devices known only by location are assumed
+// to support it.
+func checkSupported(device *DeviceInfo, method string) error {
+	if device.Support != "" && !device.Supports(method) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+	}
+	return nil
+}
+
+var (
+	capabilitiesCache = make(map[string]ColorCapabilities)
+	capabilitiesMu    sync.Mutex
+)
+
+func GetColorCapabilities(device *DeviceInfo) (ColorCapabilities, error) {
+	return GetColorCapabilitiesContext(context.Background(), device)
+}
+
+// GetColorCapabilitiesContext returns the color capabilities of the device,
+// cached per device location and model. When the device was discovered,
+// modes are inferred from its advertised methods; otherwise the device is
+// queried and a mode counts as supported if it reports a value for the
+// matching property, since firmware answers unsupported properties with "".
+// The color temperature range is looked up by device.Model.
+func GetColorCapabilitiesContext(ctx context.Context, device *DeviceInfo) (ColorCapabilities, error) {
+	cacheKey := device.Location + " " + device.Model
+	capabilitiesMu.Lock()
+	caps, cached := capabilitiesCache[cacheKey]
+	capabilitiesMu.Unlock()
+	if cached {
+		return caps, nil
+	}
+
+	if device.Support != "" {
+		caps = ColorCapabilities{
+			RGB: device.Supports("set_rgb"),
+			CT:  device.Supports("set_ct_abx"),
+			HSV: device.Supports("set_hsv"),
+		}
+	} else {
+		props, err := GetPropContext(ctx, device, "rgb", "ct", "hue")
+		if err != nil {
+			return ColorCapabilities{}, fmt.Errorf("failed to query color capabilities: %w", err)
+		}
+		caps = ColorCapabilities{
+			RGB: props["rgb"] != "",
+			CT:  props["ct"] != "",
+			HSV: props["hue"] != "",
+		}
+	}
+	if caps.CT {
+		ctRange, known := modelCTRanges[device.Model]
+		if !known {
+			ctRange = [2]int{defaultMinCT, defaultMaxCT}
+		}
+		caps.MinCT, caps.MaxCT, caps.CTRangeKnown = ctRange[0], ctRange[1], known
+	}
+
+	capabilitiesMu.Lock()
+	capabilitiesCache[cacheKey] = caps
+	capabilitiesMu.Unlock()
+
+	return caps, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// replyProps answers get_prop with values, in the order requested.
+func replyProps(values ...string) func(cmd CommandRequest) []string {
+	return func(cmd CommandRequest) []string {
+		result, _ := json.Marshal(values)
+		return []string{fmt.Sprintf(`{"id":%d,"result":%s}`, cmd.ID, result)}
+	}
+}
+
+func TestGetColorCapabilities(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    string
+		support  string
+		reply    func(cmd CommandRequest) []string
+		want     ColorCapabilities
+		wantSent int
+	}{
+		{
+			name:    "discovered with a known model",
+			model:   "lamp1",
+			support: "get_prop set_ct_abx set_rgb",
+			reply:   replyNothing,
+			want:    ColorCapabilities{RGB: true, CT: true, MinCT: 2700, MaxCT: 5000, CTRangeKnown: true},
+		},
+		{
+			name:    "discovered without color temperature",
+			model:   "color",
+			support: "get_prop set_rgb set_hsv",
+			reply:   replyNothing,
+			want:    ColorCapabilities{RGB: true, HSV: true},
+		},
+		{
+			name:     "queried with an unknown model",
+			reply:    replyProps("16711680", "4000", ""),
+			want:     ColorCapabilities{RGB: true, CT: true, MinCT: defaultMinCT, MaxCT: defaultMaxCT},
+			wantSent: 1,
+		},
+		{
+			name:     "queried rgb only",
+			model:    "strip1",
+			reply:    replyProps("255", "", ""),
+			want:     ColorCapabilities{RGB: true},
+			wantSent: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			info := device.Info()
+			info.Model = tt.model
+			info.Support = tt.support
+
+			for range 2 {
+				caps, err := GetColorCapabilities(info)
+				if err != nil {
+					t.Fatalf("GetColorCapabilities() error = %v", err)
+				}
+				if caps != tt.want {
+					t.Errorf("GetColorCapabilities() = %+v, want %+v", caps, tt.want)
+				}
+			}
+			if sent := len(device.Commands()); sent != tt.wantSent {
+				t.Errorf("device received %d commands, want %d (later calls cached)", sent, tt.wantSent)
+			}
+		})
+	}
+}
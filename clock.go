@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultClockFormat is the time layout DrawClock uses when none is given.
+const DefaultClockFormat = "15:04"
+
+// DrawClock draws t formatted with the time layout format, "15:04" when
+// empty, centered across the top of the matrix in CompactFont. HH:MM fits
+// the 20-pixel width with a pixel between glyphs; longer strings are drawn
+// without gaps if that makes them fit, and return an error otherwise.
+func DrawClock(fb *Framebuffer, t time.Time, format string, color, background Color) error {
+	if format == "" {
+		format = DefaultClockFormat
+	}
+	return drawClockText(fb, t.Format(format), color, background)
+}
+
+// DrawDuration draws d as a countdown, MM:SS below an hour and HH:MM from
+// an hour on, centered like DrawClock. Partial seconds round up, so 00:00
+// only shows once the countdown is over; negative durations are drawn as
+// 00:00.
+func DrawDuration(fb *Framebuffer, d time.Duration, color, background Color) error {
+	d = max(0, d+time.Second-1).Truncate(time.Second)
+
+	var text string
+	if d < time.Hour {
+		text = fmt.Sprintf("%02d:%02d", int(d/time.Minute), int(d%time.Minute/time.Second))
+	} else {
+		text = fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	}
+	return drawClockText(fb, text, color, background)
+}
+
+// drawClockText clears fb to background and draws text centered in
+// CompactFont, dropping the gap between glyphs when the text would not fit
+// otherwise.
+func drawClockText(fb *Framebuffer, text string, color, background Color) error {
+	spacing := 1
+	if n := len([]rune(text)); n*CompactFont.GlyphWidth+(n-1)*spacing > fb.Width {
+		spacing = 0
+	}
+
+	fb.Clear(background)
+	return drawStringFont(fb, CompactFont, text, 0, spacing, AlignCenter, DirectionLTR, color, background)
+}
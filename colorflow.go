@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlowMode selects what a FlowTuple's Value means.
+type FlowMode int
+
+const (
+	FlowModeColor FlowMode = 1 // Value is an RGB color packed as 0xRRGGBB
+	FlowModeCT    FlowMode = 2 // Value is a color temperature in kelvin
+	FlowModeSleep FlowMode = 7 // Value is ignored; the flow just waits
+)
+
+// FlowAction is what the device does once a color flow ends.
+type FlowAction int
+
+const (
+	FlowActionRecover FlowAction = 0 // restore the state before the flow
+	FlowActionStay    FlowAction = 1 // keep the state of the last step
+	FlowActionOff     FlowAction = 2 // switch the device off
+)
+
+// FlowTuple is a single step of a color flow.
+type FlowTuple struct {
+	// Duration of the step in milliseconds, at least 50.
+	Duration int
+	Mode     FlowMode
+	Value    int
+	// Brightness in percent, or -1 to keep the current brightness. Ignored
+	// for FlowModeSleep.
+	Brightness int
+}
+
+// StartColorFlow runs a color flow on the device itself, so it keeps playing
+// without network traffic. count is the number of steps to play, 0 meaning
+// forever; action is a FlowAction.
+func StartColorFlow(device *DeviceInfo, count int, action int, expressions []FlowTuple) error {
+	if count < 0 {
+		return fmt.Errorf("count must not be negative, got %d", count)
+	}
+	if action < int(FlowActionRecover) || action > int(FlowActionOff) {
+		return fmt.Errorf("action must be between 0 and 2, got %d", action)
+	}
+
+	expression, err := encodeFlowExpression(expressions)
+	if err != nil {
+		return err
+	}
+
+	response, err := SendCommand(device, "start_cf", []any{count, action, expression})
+	if err != nil {
+		return fmt.Errorf("failed to start color flow: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// StopColorFlow stops a running color flow.
+func StopColorFlow(device *DeviceInfo) error {
+	response, err := SendCommand(device, "stop_cf", []any{})
+	if err != nil {
+		return fmt.Errorf("failed to stop color flow: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// encodeFlowExpression serializes the tuples into the flat
+// "duration,mode,value,brightness,..." string start_cf expects.
+func encodeFlowExpression(expressions []FlowTuple) (string, error) {
+	if len(expressions) == 0 {
+		return "", errors.New("color flow needs at least one step")
+	}
+
+	fields := make([]string, 0, len(expressions)*4)
+	for i, tuple := range expressions {
+		if tuple.Duration < 50 {
+			return "", fmt.Errorf("step %d: duration must be at least 50ms, got %d", i, tuple.Duration)
+		}
+		if tuple.Brightness < -1 || tuple.Brightness > 100 {
+			return "", fmt.Errorf("step %d: brightness must be between -1 and 100, got %d", i, tuple.Brightness)
+		}
+		switch tuple.Mode {
+		case FlowModeColor:
+			if tuple.Value < 0 || tuple.Value > 0xFFFFFF {
+				return "", fmt.Errorf("step %d: color %d out of range", i, tuple.Value)
+			}
+		case FlowModeCT:
+			if tuple.Value < defaultMinCT || tuple.Value > defaultMaxCT {
+				return "", fmt.Errorf("step %d: color temperature must be between %d and %d, got %d",
+					i, defaultMinCT, defaultMaxCT, tuple.Value)
+			}
+		case FlowModeSleep:
+		default:
+			return "", fmt.Errorf("step %d: invalid flow mode %d", i, tuple.Mode)
+		}
+
+		fields = append(fields,
+			strconv.Itoa(tuple.Duration),
+			strconv.Itoa(int(tuple.Mode)),
+			strconv.Itoa(tuple.Value),
+			strconv.Itoa(tuple.Brightness),
+		)
+	}
+	return strings.Join(fields, ","), nil
+}
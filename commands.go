@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -34,24 +38,182 @@ func parseLocation(location string) (string, error) {
 	return addr, nil
 }
 
+// CommandTimeout bounds connecting to a device and each command exchange.
+var CommandTimeout = 3 * time.Second
+
+// commandDeadline returns the I/O deadline for a single command exchange:
+// CommandTimeout from now, shortened to the context deadline if earlier.
+func commandDeadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(CommandTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
 func SendCommand(device *DeviceInfo, method string, params []any) (*CommandResponse, error) {
-	addr, err := parseLocation(device.Location)
+	return SendCommandContext(context.Background(), device, method, params)
+}
+
+// SendCommandContext sends a single command on a fresh connection and
+// returns the device's response, skipping notifications pushed in between.
+// The exchange is limited to CommandTimeout or ctx's earlier deadline, and a
+// cancelled ctx interrupts it mid-read. Failures are logged at debug level.
+func SendCommandContext(
+	ctx context.Context,
+	device *DeviceInfo,
+	method string,
+	params []any,
+) (*CommandResponse, error) {
+	response, err := sendCommand(ctx, device, method, params)
 	if err != nil {
-		return nil, err
+		slog.Debug("Command failed", "device", device.Location, "method", method, "error", err)
 	}
+	return response, err
+}
 
-	dialer := &net.Dialer{Timeout: 3 * time.Second}
-	conn, dialErr := dialer.DialContext(context.Background(), "tcp", addr)
-	if dialErr != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w", addr, dialErr)
+func sendCommand(ctx context.Context, device *DeviceInfo, method string, params []any) (*CommandResponse, error) {
+	conn, err := dialDevice(ctx, device)
+	if err != nil {
+		return nil, contextError(ctx, err)
 	}
 	defer conn.Close()
 
-	if deadlineErr := conn.SetDeadline(time.Now().Add(3 * time.Second)); deadlineErr != nil {
+	stopAfter := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stopAfter()
+
+	if deadlineErr := conn.SetDeadline(commandDeadline(ctx)); deadlineErr != nil {
 		return nil, fmt.Errorf("failed to set deadline: %w", deadlineErr)
 	}
 
-	cmd := CommandRequest{ID: 1, Method: method, Params: params}
+	response, err := exchangeCommand(conn, bufio.NewReader(conn), CommandRequest{ID: 1, Method: method, Params: params})
+	return response, contextError(ctx, err)
+}
+
+// contextError marks err as caused by ctx when ctx is done or its deadline
+// has passed. Connection deadlines mirror ctx's, so an exchange may time out
+// just before ctx reports it; without this the failure would look like an
+// unresponsive device rather than the caller running out of time.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%w: %w", ctxErr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	}
+	return err
+}
+
+func SendCommandRetry(
+	device *DeviceInfo,
+	method string,
+	params []any,
+	attempts int,
+	backoff time.Duration,
+) (*CommandResponse, error) {
+	return SendCommandRetryContext(context.Background(), device, method, params, attempts, backoff)
+}
+
+// SendCommandRetryContext is like SendCommandContext but retries up to
+// attempts times in total when the connection fails, waiting backoff before
+// the first retry and doubling the wait each time. Errors reported by the
+// device itself are returned straight away, since repeating the command would
+// not change the answer.
+func SendCommandRetryContext(
+	ctx context.Context,
+	device *DeviceInfo,
+	method string,
+	params []any,
+	attempts int,
+	backoff time.Duration,
+) (*CommandResponse, error) {
+	var lastErr error
+	for attempt := range max(attempts, 1) {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, fmt.Errorf("gave up retrying %s: %w", method, ctx.Err())
+			}
+			backoff *= 2
+		}
+
+		response, err := SendCommandContext(ctx, device, method, params)
+		var deviceErr *DeviceError
+		if err == nil || errors.As(err, &deviceErr) {
+			return response, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("%s failed after %d attempts: %w", method, max(attempts, 1), lastErr)
+}
+
+// Command is a single method call sent as part of a batch.
+type Command struct {
+	Method string
+	Params []any
+}
+
+func SendBatch(device *DeviceInfo, commands []Command) ([]*CommandResponse, error) {
+	return SendBatchContext(context.Background(), device, commands)
+}
+
+// SendBatchContext sends commands in order over a single connection, waiting
+// for each response before sending the next one. It stops at the first
+// failure and returns the responses received so far, including the failed
+// one if the device answered with an error.
+func SendBatchContext(ctx context.Context, device *DeviceInfo, commands []Command) ([]*CommandResponse, error) {
+	conn, err := dialDevice(ctx, device)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	defer conn.Close()
+
+	stopAfter := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stopAfter()
+
+	reader := bufio.NewReader(conn)
+	responses := make([]*CommandResponse, 0, len(commands))
+	for i, command := range commands {
+		if deadlineErr := conn.SetDeadline(commandDeadline(ctx)); deadlineErr != nil {
+			return responses, fmt.Errorf("failed to set deadline: %w", deadlineErr)
+		}
+
+		cmd := CommandRequest{ID: i + 1, Method: command.Method, Params: command.Params}
+		response, exchangeErr := exchangeCommand(conn, reader, cmd)
+		if response != nil {
+			responses = append(responses, response)
+		}
+		if exchangeErr != nil {
+			exchangeErr = contextError(ctx, exchangeErr)
+			return responses, fmt.Errorf("command %d (%s) failed: %w", i+1, command.Method, exchangeErr)
+		}
+	}
+
+	return responses, nil
+}
+
+func dialDevice(ctx context.Context, device *DeviceInfo) (net.Conn, error) {
+	addr, err := parseLocation(device.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: CommandTimeout}
+	conn, dialErr := dialer.DialContext(ctx, "tcp", addr)
+	if dialErr != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, dialErr)
+	}
+	return conn, nil
+}
+
+// exchangeCommand writes cmd to conn and reads lines from reader until the
+// response carrying the same request ID arrives. Other lines, such as
+// unsolicited state notifications, are skipped.
+func exchangeCommand(conn net.Conn, reader *bufio.Reader, cmd CommandRequest) (*CommandResponse, error) {
 	cmdJSON, err := json.Marshal(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode command: %w", err)
@@ -61,31 +223,66 @@ func SendCommand(device *DeviceInfo, method string, params []any) (*CommandRespo
 		return nil, fmt.Errorf("failed to send command: %w", err)
 	}
 
-	reader := bufio.NewReader(conn)
-	responseBytes, err := reader.ReadBytes('\n')
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	for {
+		responseBytes, readErr := reader.ReadBytes('\n')
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read response: %w", readErr)
+		}
 
-	var response CommandResponse
-	if err = json.Unmarshal(responseBytes, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		var response CommandResponse
+		if err = json.Unmarshal(responseBytes, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if response.ID != cmd.ID {
+			continue
+		}
+		return &response, responseError(&response)
 	}
+}
+
+// DeviceError is an error reported by the device in a command response.
+// Firmware reuses codes for unrelated failures (-1 covers both an unsupported
+// method and an exceeded quota), so the message is part of the match.
+type DeviceError struct {
+	Code    int
+	Message string
+}
+
+func (e *DeviceError) Error() string {
+	return fmt.Sprintf("device error: [%d] %s", e.Code, e.Message)
+}
+
+// QuotaExceeded reports whether the device rejected the command because the
+// client sent too many commands.
+func (e *DeviceError) QuotaExceeded() bool {
+	return strings.Contains(e.Message, "quota exceeded")
+}
+
+// MethodNotSupported reports whether the device does not implement the
+// method.
+func (e *DeviceError) MethodNotSupported() bool {
+	return strings.Contains(e.Message, "unsupported method") || strings.Contains(e.Message, "method not supported")
+}
 
+// responseError returns the error the device reported in response, if any.
+func responseError(response *CommandResponse) error {
 	if response.Error != nil {
-		return &response, fmt.Errorf("device error: [%d] %s", response.Error.Code, response.Error.Message)
+		return &DeviceError{Code: response.Error.Code, Message: response.Error.Message}
 	}
-
-	return &response, nil
+	return nil
 }
 
 func GetProp(device *DeviceInfo, properties ...string) (map[string]string, error) {
+	return GetPropContext(context.Background(), device, properties...)
+}
+
+func GetPropContext(ctx context.Context, device *DeviceInfo, properties ...string) (map[string]string, error) {
 	params := make([]any, len(properties))
 	for i, prop := range properties {
 		params[i] = prop
 	}
 
-	response, err := SendCommand(device, "get_prop", params)
+	response, err := SendCommandContext(ctx, device, "get_prop", params)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +303,105 @@ func GetProp(device *DeviceInfo, properties ...string) (map[string]string, error
 	return result, nil
 }
 
+// GetPowerState reports whether the device is switched on.
+func GetPowerState(device *DeviceInfo) (bool, error) {
+	props, err := GetProp(device, "power")
+	if err != nil {
+		return false, fmt.Errorf("failed to get power state: %w", err)
+	}
+	return parsePowerState(props["power"])
+}
+
+// GetBrightness returns the device brightness in percent.
+func GetBrightness(device *DeviceInfo) (int, error) {
+	props, err := GetProp(device, "bright")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get brightness: %w", err)
+	}
+	return parseBrightness(props["bright"])
+}
+
+func parsePowerState(value string) (bool, error) {
+	switch value {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected power state %q", value)
+	}
+}
+
+func parseBrightness(value string) (int, error) {
+	brightness, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected brightness %q: %w", value, err)
+	}
+	if brightness < 0 || brightness > 100 {
+		return 0, fmt.Errorf("brightness %d out of range", brightness)
+	}
+	return brightness, nil
+}
+
+// SetPower switches the device on or off. effect and duration are as for
+// SetRGB.
+func SetPower(device *DeviceInfo, on bool, effect string, duration int) error {
+	return SetPowerContext(context.Background(), device, on, effect, duration)
+}
+
+// SetPowerContext checks effect and duration before dialing, so an invalid
+// transition fails without touching the device, then sends set_power.
+func SetPowerContext(ctx context.Context, device *DeviceInfo, on bool, effect string, duration int) error {
+	if err := validateEffect(effect, duration); err != nil {
+		return err
+	}
+
+	state := "off"
+	if on {
+		state = "on"
+	}
+	response, err := SendCommandContext(ctx, device, "set_power", []any{state, effect, duration})
+	if err != nil {
+		return fmt.Errorf("failed to set power: %w", err)
+	}
+
+	return expectOK(response)
+}
+
 func TogglePower(device *DeviceInfo) error {
 	response, err := SendCommand(device, "toggle", []any{})
 	if err != nil {
 		return fmt.Errorf("failed to toggle power: %w", err)
 	}
 
+	return expectOK(response)
+}
+
+// parseNotification extracts the properties of a "props" notification.
+// Notifications differ from command responses by having no request ID.
+func parseNotification(line []byte) (map[string]string, bool) {
+	var message struct {
+		ID     *int           `json:"id"`
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil || message.ID != nil || message.Method != "props" {
+		return nil, false
+	}
+
+	props := make(map[string]string, len(message.Params))
+	for key, value := range message.Params {
+		if str, ok := value.(string); ok {
+			props[key] = str
+		} else {
+			props[key] = fmt.Sprintf("%v", value)
+		}
+	}
+	return props, true
+}
+
+// expectOK checks that the device acknowledged a command with "ok".
+func expectOK(response *CommandResponse) error {
 	if len(response.Result) > 0 {
 		if result, ok := response.Result[0].(string); ok && result == "ok" {
 			return nil
@@ -121,60 +411,314 @@ func TogglePower(device *DeviceInfo) error {
 	return fmt.Errorf("unexpected response from device: %+v", response.Result)
 }
 
+// ErrNameNotApplied is returned by SetDeviceName when the device acknowledged
+// set_name but kept its previous name, which some firmware versions do.
+var ErrNameNotApplied = errors.New("device did not apply the new name")
+
+func SetDeviceName(device *DeviceInfo, name string) (string, error) {
+	return SetDeviceNameContext(context.Background(), device, name)
+}
+
+// SetDeviceNameContext renames the device and reads the name back to confirm
+// it was stored. It returns the name the device reports afterwards.
+func SetDeviceNameContext(ctx context.Context, device *DeviceInfo, name string) (string, error) {
+	response, err := SendCommandContext(ctx, device, "set_name", []any{name})
+	if err != nil {
+		return "", fmt.Errorf("failed to set name: %w", err)
+	}
+	if okErr := expectOK(response); okErr != nil {
+		return "", okErr
+	}
+
+	props, err := GetPropContext(ctx, device, "name")
+	if err != nil {
+		return "", fmt.Errorf("failed to verify name: %w", err)
+	}
+	if props["name"] != name {
+		return props["name"], fmt.Errorf("%w: device reports %q", ErrNameNotApplied, props["name"])
+	}
+
+	return props["name"], nil
+}
+
+func validateLedPayload(rgbData string) error {
+	if _, decodeErr := base64.StdEncoding.DecodeString(rgbData); decodeErr != nil {
+		return fmt.Errorf(
+			"%w: %d bytes of rgb data are not valid base64: %w", ErrInvalidPayload, len(rgbData), decodeErr,
+		)
+	}
+	return nil
+}
+
+// UpdateLedsIfChanged sends cur to the device unless it has the same pixels
+// as prev, and reports whether anything was sent. A nil prev always sends.
+// The device has no partial update command, so a changed frame is sent in
+// full.
+func UpdateLedsIfChanged(device *DeviceInfo, prev, cur *Framebuffer) (bool, error) {
+	if prev != nil && prev.Equal(cur) {
+		return false, nil
+	}
+	if err := UpdateFramebuffer(device, cur); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func encodeRGBColor(r, g, b uint8) string {
 	return base64.StdEncoding.EncodeToString([]byte{r, g, b})
 }
 
 // ActivateFxMode activates direct mode for manual LED control on Matrix devices.
 func ActivateFxMode(device *DeviceInfo) error {
+	if err := checkSupported(device, "activate_fx_mode"); err != nil {
+		return err
+	}
 	params := []any{map[string]string{"mode": "direct"}}
 	response, err := SendCommand(device, "activate_fx_mode", params)
 	if err != nil {
 		return fmt.Errorf("failed to activate fx mode: %w", err)
 	}
 
-	if len(response.Result) > 0 {
-		if result, ok := response.Result[0].(string); ok && result == "ok" {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("unexpected response from device: %+v", response.Result)
+	return expectOK(response)
 }
 
+// ErrBrightnessOutOfRange is returned by SetBrightness for values outside
+// 1–100.
+var ErrBrightnessOutOfRange = errors.New("brightness must be between 1 and 100")
+
 func SetBrightness(device *DeviceInfo, brightness int) error {
+	return SetBrightnessContext(context.Background(), device, brightness)
+}
+
+// SetBrightnessContext rejects values outside 1–100 with
+// ErrBrightnessOutOfRange before dialing, then applies the brightness at once,
+// without a smooth transition.
+func SetBrightnessContext(ctx context.Context, device *DeviceInfo, brightness int) error {
 	if brightness < 1 || brightness > 100 {
-		return fmt.Errorf("brightness must be between 1 and 100, got %d", brightness)
+		return fmt.Errorf("%w, got %d", ErrBrightnessOutOfRange, brightness)
 	}
 
-	response, err := SendCommand(device, "set_bright", []any{brightness, "sudden", 0})
+	response, err := SendCommandContext(ctx, device, "set_bright", []any{brightness, "sudden", 0})
 	if err != nil {
 		return fmt.Errorf("failed to set brightness: %w", err)
 	}
 
-	if len(response.Result) > 0 {
-		if result, ok := response.Result[0].(string); ok && result == "ok" {
-			return nil
-		}
+	return expectOK(response)
+}
+
+// AdjustBrightness changes the brightness by percentage (-100–100) of the
+// full range, relative to the current value, over duration milliseconds.
+func AdjustBrightness(device *DeviceInfo, percentage int, duration int) error {
+	return AdjustBrightnessContext(context.Background(), device, percentage, duration)
+}
+
+// AdjustBrightnessContext is AdjustBrightness bounded by ctx.
+func AdjustBrightnessContext(ctx context.Context, device *DeviceInfo, percentage int, duration int) error {
+	return adjust(ctx, device, "adjust_bright", "brightness", percentage, duration)
+}
+
+// AdjustColorTemperature changes the color temperature by percentage
+// (-100–100) of the supported range, relative to the current value.
+func AdjustColorTemperature(device *DeviceInfo, percentage int, duration int) error {
+	return AdjustColorTemperatureContext(context.Background(), device, percentage, duration)
+}
+
+// AdjustColorTemperatureContext is AdjustColorTemperature bounded by ctx.
+func AdjustColorTemperatureContext(ctx context.Context, device *DeviceInfo, percentage int, duration int) error {
+	return adjust(ctx, device, "adjust_ct", "color temperature", percentage, duration)
+}
+
+// AdjustColor changes the color by percentage (-100–100), relative to the
+// current color.
+func AdjustColor(device *DeviceInfo, percentage int, duration int) error {
+	return AdjustColorContext(context.Background(), device, percentage, duration)
+}
+
+// AdjustColorContext is AdjustColor bounded by ctx.
+func AdjustColorContext(ctx context.Context, device *DeviceInfo, percentage int, duration int) error {
+	return adjust(ctx, device, "adjust_color", "color", percentage, duration)
+}
+
+// adjust sends one of the adjust_* commands, which share their parameters;
+// what names the adjusted property in errors. Invalid values and methods the
+// device does not support fail before dialing.
+func adjust(ctx context.Context, device *DeviceInfo, method, what string, percentage int, duration int) error {
+	if percentage < -100 || percentage > 100 {
+		return fmt.Errorf("percentage must be between -100 and 100, got %d", percentage)
+	}
+	if duration < 0 {
+		return fmt.Errorf("duration must not be negative, got %d", duration)
+	}
+	if err := checkSupported(device, method); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("unexpected response from device: %+v", response.Result)
+	response, err := SendCommandContext(ctx, device, method, []any{percentage, duration})
+	if err != nil {
+		return fmt.Errorf("failed to adjust %s: %w", what, err)
+	}
+
+	return expectOK(response)
 }
 
-func SendCommandNoResponse(device *DeviceInfo, method string, params []any) error {
-	addr, err := parseLocation(device.Location)
+// SetRGB sets a solid color through the regular light path, outside fx mode.
+// effect is "sudden" or "smooth"; duration is the smooth transition time in
+// milliseconds and is ignored for sudden changes.
+func SetRGB(device *DeviceInfo, r, g, b uint8, effect string, duration int) error {
+	if err := validateEffect(effect, duration); err != nil {
+		return err
+	}
+
+	rgb := int(r)<<16 | int(g)<<8 | int(b)
+	response, err := SendCommand(device, "set_rgb", []any{rgb, effect, duration})
 	if err != nil {
+		return fmt.Errorf("failed to set rgb: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// SetHSV sets a solid color from hue (0–359) and saturation (0–100) through
+// the regular light path. effect and duration are as for SetRGB.
+func SetHSV(device *DeviceInfo, hue int, sat int, effect string, duration int) error {
+	if hue < 0 || hue > 359 {
+		return fmt.Errorf("hue must be between 0 and 359, got %d", hue)
+	}
+	if sat < 0 || sat > 100 {
+		return fmt.Errorf("saturation must be between 0 and 100, got %d", sat)
+	}
+	if err := validateEffect(effect, duration); err != nil {
 		return err
 	}
 
-	dialer := &net.Dialer{Timeout: 3 * time.Second}
-	conn, dialErr := dialer.DialContext(context.Background(), "tcp", addr)
-	if dialErr != nil {
-		return fmt.Errorf("failed to connect to %s: %w", addr, dialErr)
+	response, err := SendCommand(device, "set_hsv", []any{hue, sat, effect, duration})
+	if err != nil {
+		return fmt.Errorf("failed to set hsv: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// sceneParams is the number of parameters each set_scene type takes:
+// color takes an RGB value and brightness, hsv hue, saturation and
+// brightness, ct a color temperature and brightness, and auto_delay_off a
+// brightness and the minutes until the light turns off.
+var sceneParams = map[string]int{
+	"color":          2,
+	"hsv":            3,
+	"ct":             2,
+	"auto_delay_off": 2,
+}
+
+// SetScene sets color and brightness in one command, avoiding the flicker
+// between separate set_rgb and set_bright calls. sceneType is "color",
+// "hsv", "ct" or "auto_delay_off", and params are the values for that type
+// in protocol order, e.g. SetScene(device, "color", 0xff0000, 50).
+func SetScene(device *DeviceInfo, sceneType string, params ...any) error {
+	want, ok := sceneParams[sceneType]
+	if !ok {
+		return fmt.Errorf("unknown scene type %q", sceneType)
+	}
+	if len(params) != want {
+		return fmt.Errorf("scene type %q takes %d parameters, got %d", sceneType, want, len(params))
+	}
+	if err := checkSupported(device, "set_scene"); err != nil {
+		return err
+	}
+
+	response, err := SendCommand(device, "set_scene", append([]any{sceneType}, params...))
+	if err != nil {
+		return fmt.Errorf("failed to set scene: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// cronPowerOff is the cron job type that turns the device off; it is the
+// only type the protocol defines.
+const cronPowerOff = 0
+
+// SetAutoOff makes the device turn itself off after minutes, replacing any
+// auto-off already scheduled.
+func SetAutoOff(device *DeviceInfo, minutes int) error {
+	if minutes <= 0 {
+		return fmt.Errorf("auto-off minutes must be positive, got %d", minutes)
+	}
+
+	response, err := SendCommand(device, "cron_add", []any{cronPowerOff, minutes})
+	if err != nil {
+		return fmt.Errorf("failed to set auto-off: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// GetAutoOff returns the minutes left until the device turns itself off, or
+// 0 when no auto-off is scheduled.
+func GetAutoOff(device *DeviceInfo) (int, error) {
+	response, err := SendCommand(device, "cron_get", []any{cronPowerOff})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get auto-off: %w", err)
+	}
+	if len(response.Result) == 0 || response.Result[0] == nil {
+		return 0, nil
+	}
+
+	job, ok := response.Result[0].(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("unexpected response from device: %+v", response.Result)
+	}
+	delay, ok := job["delay"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected auto-off delay %v", job["delay"])
+	}
+	return int(delay), nil
+}
+
+// ClearAutoOff cancels a scheduled auto-off.
+func ClearAutoOff(device *DeviceInfo) error {
+	response, err := SendCommand(device, "cron_del", []any{cronPowerOff})
+	if err != nil {
+		return fmt.Errorf("failed to clear auto-off: %w", err)
+	}
+
+	return expectOK(response)
+}
+
+// validateEffect checks the effect and duration parameters shared by the
+// set_* commands. The device rejects smooth transitions shorter than 30ms.
+func validateEffect(effect string, duration int) error {
+	switch effect {
+	case "sudden":
+		return nil
+	case "smooth":
+		if duration < 30 {
+			return fmt.Errorf("smooth effect duration must be at least 30ms, got %d", duration)
+		}
+		return nil
+	default:
+		return fmt.Errorf("effect must be \"sudden\" or \"smooth\", got %q", effect)
+	}
+}
+
+func SendCommandNoResponse(device *DeviceInfo, method string, params []any) error {
+	return SendCommandNoResponseContext(context.Background(), device, method, params)
+}
+
+// SendCommandNoResponseContext writes the command and closes the connection
+// without waiting for a reply. Only the write is bounded by ctx, since nothing
+// is read back.
+func SendCommandNoResponseContext(ctx context.Context, device *DeviceInfo, method string, params []any) error {
+	conn, err := dialDevice(ctx, device)
+	if err != nil {
+		return err
 	}
 	defer conn.Close()
 
-	if writeDeadlineErr := conn.SetWriteDeadline(time.Now().Add(3 * time.Second)); writeDeadlineErr != nil {
+	stopAfter := context.AfterFunc(ctx, func() { _ = conn.SetWriteDeadline(time.Now()) })
+	defer stopAfter()
+
+	if writeDeadlineErr := conn.SetWriteDeadline(commandDeadline(ctx)); writeDeadlineErr != nil {
 		return fmt.Errorf("failed to set write deadline: %w", writeDeadlineErr)
 	}
 
@@ -191,11 +735,44 @@ func SendCommandNoResponse(device *DeviceInfo, method string, params []any) erro
 	return nil
 }
 
+// ErrInvalidPayload is returned by UpdateLeds when the RGB data is not valid
+// base64 and would be rejected by the device.
+var ErrInvalidPayload = errors.New("invalid LED payload")
+
 // UpdateLeds sends base64-encoded RGB data to update all LEDs on the Matrix device.
 // ActivateFxMode must be called before using this function.
 func UpdateLeds(device *DeviceInfo, rgbData string) error {
+	if err := validateLedPayload(rgbData); err != nil {
+		return err
+	}
+	if err := checkSupported(device, "update_leds"); err != nil {
+		return err
+	}
+
+	unlock := lockLedUpdates(device.Location)
+	defer unlock()
 	if err := SendCommandNoResponse(device, "update_leds", []any{rgbData}); err != nil {
 		return fmt.Errorf("failed to update LEDs: %w", err)
 	}
 	return nil
 }
+
+// UpdateFramebuffer encodes fb and sends it to the device like UpdateLeds.
+// Prefer it over UpdateLeds with fb.Encode(): it is the one place frames are
+// turned into the device payload.
+func UpdateFramebuffer(device *DeviceInfo, fb *Framebuffer) error {
+	return UpdateLeds(device, fb.Encode())
+}
+
+// ledUpdateLocks holds a *sync.Mutex per device location.
+var ledUpdateLocks sync.Map
+
+// lockLedUpdates serializes LED updates to the device at location across
+// all callers, such as a preview socket and a running animation, so their
+// frames are sent whole one after another. It returns the unlock function.
+func lockLedUpdates(location string) func() {
+	lock, _ := ledUpdateLocks.LoadOrStore(location, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
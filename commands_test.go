@@ -0,0 +1,534 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDevice is a device on a local TCP port. Every command it receives is
+// recorded and answered with the lines reply returns for it; no lines leaves
+// the command unanswered.
+type fakeDevice struct {
+	listener net.Listener
+	reply    func(cmd CommandRequest) []string
+
+	mu          sync.Mutex
+	commands    []CommandRequest
+	connections []net.Conn
+	writeMu     sync.Mutex
+}
+
+func newFakeDevice(t *testing.T, reply func(cmd CommandRequest) []string) *fakeDevice {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	device := &fakeDevice{listener: listener, reply: reply}
+	go device.serve()
+	t.Cleanup(device.close)
+	return device
+}
+
+func (d *fakeDevice) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		d.mu.Lock()
+		d.connections = append(d.connections, conn)
+		d.mu.Unlock()
+		go d.handle(conn)
+	}
+}
+
+func (d *fakeDevice) handle(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var cmd CommandRequest
+		if err := json.Unmarshal(scanner.Bytes(), &cmd); err != nil {
+			return
+		}
+		d.mu.Lock()
+		d.commands = append(d.commands, cmd)
+		d.mu.Unlock()
+
+		for _, line := range d.reply(cmd) {
+			if err := d.write(conn, line); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write sends line on conn; writes are serialized so replies and
+// notifications never interleave.
+func (d *fakeDevice) write(conn net.Conn, line string) error {
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+	_, err := conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (d *fakeDevice) close() {
+	d.listener.Close()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, conn := range d.connections {
+		conn.Close()
+	}
+}
+
+// Info returns a DeviceInfo pointing at the fake device.
+func (d *fakeDevice) Info() *DeviceInfo {
+	return &DeviceInfo{Location: d.Location()}
+}
+
+func (d *fakeDevice) Location() string {
+	return "yeelight://" + d.listener.Addr().String()
+}
+
+// Commands returns the commands received so far.
+func (d *fakeDevice) Commands() []CommandRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]CommandRequest(nil), d.commands...)
+}
+
+// Connections returns how many connections were opened to the device.
+func (d *fakeDevice) Connections() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.connections)
+}
+
+// replyOK acknowledges every command.
+func replyOK(cmd CommandRequest) []string {
+	return []string{fmt.Sprintf(`{"id":%d,"result":["ok"]}`, cmd.ID)}
+}
+
+// replyNothing never answers, like a device that hangs.
+func replyNothing(CommandRequest) []string {
+	return nil
+}
+
+func TestSendCommandContextDeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   func(cmd CommandRequest) []string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{name: "answered", reply: replyOK, timeout: time.Second},
+		{name: "silent device", reply: replyNothing, timeout: 50 * time.Millisecond, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			start := time.Now()
+			_, err := SendCommandContext(ctx, device.Info(), "get_prop", []any{"power"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendCommandContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var netErr net.Error
+			if tt.wantErr && (!errors.As(err, &netErr) || !netErr.Timeout()) {
+				t.Errorf("SendCommandContext() error = %v, want a timeout", err)
+			}
+			if elapsed := time.Since(start); elapsed >= CommandTimeout {
+				t.Errorf("SendCommandContext() took %s, want it bounded by the context deadline", elapsed)
+			}
+		})
+	}
+}
+
+// replyError answers every command with a device error.
+func replyError(cmd CommandRequest) []string {
+	return []string{fmt.Sprintf(`{"id":%d,"error":{"code":-1,"message":"unsupported method"}}`, cmd.ID)}
+}
+
+func TestSendBatchContext(t *testing.T) {
+	commands := []Command{
+		{Method: "set_power", Params: []any{"on", "sudden", 0}},
+		{Method: "set_bright", Params: []any{50, "sudden", 0}},
+		{Method: "set_rgb", Params: []any{0xff0000, "sudden", 0}},
+	}
+
+	tests := []struct {
+		name          string
+		reply         func(cmd CommandRequest) []string
+		wantResponses int
+		wantSent      int
+		wantDeviceErr bool
+	}{
+		{name: "all acknowledged", reply: replyOK, wantResponses: 3, wantSent: 3},
+		{
+			name: "stops at the first device error",
+			reply: func(cmd CommandRequest) []string {
+				if cmd.Method == "set_bright" {
+					return replyError(cmd)
+				}
+				return replyOK(cmd)
+			},
+			wantResponses: 2,
+			wantSent:      2,
+			wantDeviceErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			responses, err := SendBatchContext(context.Background(), device.Info(), commands)
+
+			var deviceErr *DeviceError
+			if errors.As(err, &deviceErr) != tt.wantDeviceErr {
+				t.Fatalf("SendBatchContext() error = %v, want device error %v", err, tt.wantDeviceErr)
+			}
+			if !tt.wantDeviceErr && err != nil {
+				t.Fatalf("SendBatchContext() error = %v", err)
+			}
+			if len(responses) != tt.wantResponses {
+				t.Errorf("got %d responses, want %d", len(responses), tt.wantResponses)
+			}
+			if sent := len(device.Commands()); sent != tt.wantSent {
+				t.Errorf("device received %d commands, want %d", sent, tt.wantSent)
+			}
+			if conns := device.Connections(); conns != 1 {
+				t.Errorf("batch opened %d connections, want 1", conns)
+			}
+		})
+	}
+}
+
+func TestExchangeCommandSkipsOtherLines(t *testing.T) {
+	notification := `{"method":"props","params":{"power":"off"}}`
+
+	tests := []struct {
+		name  string
+		reply func(cmd CommandRequest) []string
+	}{
+		{name: "response only", reply: replyOK},
+		{
+			name: "notification first",
+			reply: func(cmd CommandRequest) []string {
+				return append([]string{notification}, replyOK(cmd)...)
+			},
+		},
+		{
+			name: "stale response first",
+			reply: func(cmd CommandRequest) []string {
+				stale := fmt.Sprintf(`{"id":%d,"result":["stale"]}`, cmd.ID+100)
+				return append([]string{stale, notification}, replyOK(cmd)...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			response, err := SendCommand(device.Info(), "set_power", []any{"on", "sudden", 0})
+			if err != nil {
+				t.Fatalf("SendCommand() error = %v", err)
+			}
+			if response.ID != 1 || len(response.Result) != 1 || response.Result[0] != "ok" {
+				t.Errorf("SendCommand() = %+v, want the acknowledgement of the command", response)
+			}
+		})
+	}
+}
+
+// namedDevice answers set_name and get_prop name like firmware that stores
+// the name only when applies is set.
+func namedDevice(applies bool) func(cmd CommandRequest) []string {
+	var mu sync.Mutex
+	name := "old"
+	return func(cmd CommandRequest) []string {
+		mu.Lock()
+		defer mu.Unlock()
+		switch cmd.Method {
+		case "set_name":
+			if applies {
+				name, _ = cmd.Params[0].(string)
+			}
+			return replyOK(cmd)
+		case "get_prop":
+			return replyProps(name)(cmd)
+		}
+		return replyError(cmd)
+	}
+}
+
+func TestSetDeviceName(t *testing.T) {
+	tests := []struct {
+		name          string
+		reply         func(cmd CommandRequest) []string
+		wantName      string
+		wantErr       error
+		wantDeviceErr bool
+	}{
+		{name: "name applied", reply: namedDevice(true), wantName: "desk"},
+		{name: "name kept by firmware", reply: namedDevice(false), wantName: "old", wantErr: ErrNameNotApplied},
+		{name: "rename rejected", reply: replyError, wantDeviceErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			got, err := SetDeviceName(device.Info(), "desk")
+
+			var deviceErr *DeviceError
+			if tt.wantDeviceErr {
+				if !errors.As(err, &deviceErr) {
+					t.Fatalf("SetDeviceName() error = %v, want a device error", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetDeviceName() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantName {
+				t.Errorf("SetDeviceName() = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+// Notify pushes a props notification with the given properties to every
+// connection, like a device reporting a state change.
+func (d *fakeDevice) Notify(props map[string]string) {
+	data, _ := json.Marshal(map[string]any{"method": "props", "params": props})
+	d.mu.Lock()
+	connections := append([]net.Conn(nil), d.connections...)
+	d.mu.Unlock()
+	for _, conn := range connections {
+		_ = d.write(conn, string(data))
+	}
+}
+
+// waitFor polls condition until it holds, failing the test after a second.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestValidateLedPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr bool
+	}{
+		{name: "encoded frame", payload: NewMatrixFramebuffer().Encode()},
+		{name: "single pixel", payload: encodeRGBColor(255, 0, 0)},
+		{name: "empty", payload: ""},
+		{name: "not base64", payload: "not base64!", wantErr: true},
+		{name: "truncated", payload: "AAA", wantErr: true},
+		{name: "url alphabet", payload: "__8A", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLedPayload(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateLedPayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("validateLedPayload() error = %v, want %v", err, ErrInvalidPayload)
+			}
+		})
+	}
+}
+
+func TestUpdateLedsRejectsInvalidPayload(t *testing.T) {
+	device := newFakeDevice(t, replyOK)
+	if err := UpdateLeds(device.Info(), "not base64!"); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("UpdateLeds() error = %v, want %v", err, ErrInvalidPayload)
+	}
+	if conns := device.Connections(); conns != 0 {
+		t.Errorf("UpdateLeds() opened %d connections for an invalid payload, want none", conns)
+	}
+}
+
+func TestParsePowerState(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{value: "on", want: true},
+		{value: "off", want: false},
+		{value: "", wantErr: true},
+		{value: "ON", wantErr: true},
+		{value: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parsePowerState(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePowerState(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parsePowerState(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBrightness(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "0", want: 0},
+		{value: "42", want: 42},
+		{value: "100", want: 100},
+		{value: "", wantErr: true},
+		{value: "50%", wantErr: true},
+		{value: "12.5", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "101", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseBrightness(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBrightness(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseBrightness(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// replyPropValues answers get_prop with the values of the requested
+// properties in props.
+func replyPropValues(props map[string]string) func(cmd CommandRequest) []string {
+	return func(cmd CommandRequest) []string {
+		values := make([]string, len(cmd.Params))
+		for i, param := range cmd.Params {
+			name, _ := param.(string)
+			values[i] = props[name]
+		}
+		return replyProps(values...)(cmd)
+	}
+}
+
+func TestGetPowerStateAndBrightness(t *testing.T) {
+	tests := []struct {
+		name          string
+		reply         func(cmd CommandRequest) []string
+		wantOn        bool
+		wantBright    int
+		wantErr       bool
+		wantDeviceErr bool
+	}{
+		{
+			name:   "on at 80%",
+			reply:  replyPropValues(map[string]string{"power": "on", "bright": "80"}),
+			wantOn: true, wantBright: 80,
+		},
+		{
+			name:   "off",
+			reply:  replyPropValues(map[string]string{"power": "off", "bright": "1"}),
+			wantOn: false, wantBright: 1,
+		},
+		{
+			name:    "malformed values",
+			reply:   replyPropValues(map[string]string{"power": "maybe", "bright": "bright"}),
+			wantErr: true,
+		},
+		{name: "missing values", reply: replyPropValues(nil), wantErr: true},
+		{name: "device error", reply: replyError, wantErr: true, wantDeviceErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+
+			on, err := GetPowerState(device.Info())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPowerState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var deviceErr *DeviceError
+			if tt.wantDeviceErr && !errors.As(err, &deviceErr) {
+				t.Errorf("GetPowerState() error = %v, want a device error", err)
+			}
+			if on != tt.wantOn {
+				t.Errorf("GetPowerState() = %v, want %v", on, tt.wantOn)
+			}
+
+			bright, err := GetBrightness(device.Info())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBrightness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if bright != tt.wantBright {
+				t.Errorf("GetBrightness() = %v, want %v", bright, tt.wantBright)
+			}
+		})
+	}
+}
+
+func TestAdjustContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		support  string
+		cancel   bool
+		wantErr  error
+		wantSent int
+	}{
+		{name: "device known only by location", wantSent: 1},
+		{name: "advertised", support: "get_prop adjust_bright adjust_ct adjust_color", wantSent: 1},
+		{name: "not advertised", support: "get_prop set_bright", wantErr: ErrUnsupportedMethod},
+		{name: "cancelled", cancel: true, wantErr: context.Canceled},
+	}
+	adjusts := []struct {
+		method string
+		call   func(context.Context, *DeviceInfo, int, int) error
+	}{
+		{method: "adjust_bright", call: AdjustBrightnessContext},
+		{method: "adjust_ct", call: AdjustColorTemperatureContext},
+		{method: "adjust_color", call: AdjustColorContext},
+	}
+
+	for _, tt := range tests {
+		for _, adj := range adjusts {
+			t.Run(tt.name+"/"+adj.method, func(t *testing.T) {
+				device := newFakeDevice(t, replyOK)
+				info := device.Info()
+				info.Support = tt.support
+				ctx, cancel := context.WithCancel(context.Background())
+				if tt.cancel {
+					cancel()
+				}
+				defer cancel()
+
+				if err := adj.call(ctx, info, 10, 0); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("%s error = %v, want %v", adj.method, err, tt.wantErr)
+				}
+				if sent := countCommands(device.Commands(), adj.method); sent != tt.wantSent {
+					t.Errorf("%s sent %d times, want %d", adj.method, sent, tt.wantSent)
+				}
+			})
+		}
+	}
+}
@@ -2,13 +2,31 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type Config struct {
-	ServerPort   string `env:"SERVER_PORT"    envDefault:"9080"`
-	ServerDBPath string `env:"SERVER_DB_PATH" envDefault:"cubik.db"`
+	ServerHost         string        `env:"SERVER_HOST"`
+	ServerPort         string        `env:"SERVER_PORT"            envDefault:"9080"`
+	ServerDBPath       string        `env:"SERVER_DB_PATH"         envDefault:"cubik.db"`
+	RequestTimeout     time.Duration `env:"REQUEST_TIMEOUT"        envDefault:"10s"`
+	AnimationCacheSize int           `env:"ANIMATION_CACHE_SIZE"   envDefault:"64"`
+	ReachabilityTTL    time.Duration `env:"REACHABILITY_TTL"       envDefault:"5s"`
+	AnimationMinFPS    float64       `env:"ANIMATION_MIN_FPS"      envDefault:"0.2"`
+	AnimationMaxFPS    float64       `env:"ANIMATION_MAX_FPS"      envDefault:"10"`
+	CommandTimeout     time.Duration `env:"COMMAND_TIMEOUT"        envDefault:"3s"`
+	DiscoveryTimeout   time.Duration `env:"DISCOVERY_TIMEOUT"      envDefault:"3s"`
+	DiscoveryIface     string        `env:"SERVER_DISCOVERY_IFACE"`
+	MaxRequestBytes    int64         `env:"MAX_REQUEST_BYTES"      envDefault:"8388608"`
+	CORSOrigins        []string      `env:"CORS_ORIGINS"           envDefault:"*"  envSeparator:","`
+	Simulate           bool          `env:"SIMULATE"`
+	SimulationDir      string        `env:"SIMULATION_DIR"`
+	LogLevel           slog.Level    `env:"LOG_LEVEL"              envDefault:"info"`
+	LogFormat          string        `env:"LOG_FORMAT"             envDefault:"text"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -18,3 +36,17 @@ func LoadConfig() (*Config, error) {
 	}
 	return &cfg, nil
 }
+
+// NewLogger returns a logger writing records at cfg.LogLevel and above to
+// stderr, as logfmt-style text or, for log collectors, as JSON.
+func NewLogger(cfg *Config) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: cfg.LogLevel}
+	switch cfg.LogFormat {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid LOG_FORMAT %q: expected text or json", cfg.LogFormat)
+	}
+}
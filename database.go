@@ -3,14 +3,39 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"time"
 
 	_ "modernc.org/sqlite"
 )
 
+// dbPragmas are applied to every connection of the pool as it is opened;
+// foreign_keys and busy_timeout are per-connection settings, so running them
+// once after opening would only configure a single connection.
+var dbPragmas = []string{
+	"journal_mode(WAL)",
+	"foreign_keys(1)",
+	"busy_timeout(5000)",
+}
+
+// InitDB opens the SQLite database at dbPath, creating the file and its
+// parent directories if needed, e.g. on a freshly mounted volume.
 func InitDB(ctx context.Context, dbPath string) (*sql.DB, error) {
-	dbURI := fmt.Sprintf("file:%s?cache=shared&mode=rwc", dbPath)
+	if dir := filepath.Dir(dbPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	query := url.Values{"cache": {"shared"}, "mode": {"rwc"}}
+	for _, pragma := range dbPragmas {
+		query.Add("_pragma", pragma)
+	}
+	dbURI := fmt.Sprintf("file:%s?%s", dbPath, query.Encode())
 	db, err := sql.Open("sqlite", dbURI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -22,30 +47,27 @@ func InitDB(ctx context.Context, dbPath string) (*sql.DB, error) {
 
 	if pingErr := db.PingContext(ctx); pingErr != nil {
 		db.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", pingErr)
-	}
-
-	pragmas := []string{
-		"PRAGMA journal_mode = WAL",
-		"PRAGMA foreign_keys = ON",
-		"PRAGMA busy_timeout = 5000",
-	}
-	for _, pragma := range pragmas {
-		if _, execErr := db.ExecContext(ctx, pragma); execErr != nil {
-			db.Close()
-			return nil, fmt.Errorf("failed to execute %s: %w", pragma, execErr)
-		}
+		return nil, fmt.Errorf("failed to ping database %s: %w", dbPath, pingErr)
 	}
 
 	return db, nil
 }
 
+// CloseDB checkpoints the WAL into the database file and truncates it, so no
+// large -wal file is left behind, then closes the database. It must be
+// called once nothing uses db anymore: run does so after the server has
+// drained its requests and stopped all animations.
 func CloseDB(db *sql.DB) error {
 	if db == nil {
 		return nil
 	}
+
+	var checkpointErr error
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		checkpointErr = fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
 	if err := db.Close(); err != nil {
-		return fmt.Errorf("failed to close database: %w", err)
+		return errors.Join(checkpointErr, fmt.Errorf("failed to close database: %w", err))
 	}
-	return nil
+	return checkpointErr
 }
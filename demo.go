@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math"
 	"math/rand/v2"
-	"strings"
 	"time"
 )
 
@@ -40,23 +39,14 @@ func demoTestPatterns(device *DeviceInfo) {
 }
 
 func demoDigitDisplay(device *DeviceInfo) {
-	fb := NewFramebuffer(20, 5)
+	fb := NewMatrixFramebuffer()
 	black := Color{R: 0, G: 0, B: 0}
 
 	// Demo 1: Single digits 0-9 in green
 	green := Color{R: 0, G: 255, B: 0}
 	fmt.Println("\n  Demo: Single digits 0-9")
 	for i := range 10 {
-		fb.Clear(black)
-		if err := DrawNumber(fb, i, 0, 0, AlignCenter, green, black); err != nil {
-			fmt.Printf("  Error drawing number: %v\n", err)
-			continue
-		}
-		if err := UpdateLeds(device, fb.Encode()); err != nil {
-			fmt.Printf("  Error updating LEDs: %v\n", err)
-			continue
-		}
-		time.Sleep(500 * time.Millisecond)
+		showDemoNumber(device, fb, i, 0, AlignCenter, green, black, 500*time.Millisecond)
 	}
 
 	// Demo 2: Multi-digit numbers in blue
@@ -64,32 +54,14 @@ func demoDigitDisplay(device *DeviceInfo) {
 	numbers := []int{42, 123, 999, 2025}
 	fmt.Println("\n  Demo: Multi-digit numbers")
 	for _, num := range numbers {
-		fb.Clear(black)
-		if err := DrawNumber(fb, num, 0, 1, AlignCenter, blue, black); err != nil {
-			fmt.Printf("  Error drawing number: %v\n", err)
-			continue
-		}
-		if err := UpdateLeds(device, fb.Encode()); err != nil {
-			fmt.Printf("  Error updating LEDs: %v\n", err)
-			continue
-		}
-		time.Sleep(1 * time.Second)
+		showDemoNumber(device, fb, num, 1, AlignCenter, blue, black, 1*time.Second)
 	}
 
 	// Demo 3: Countdown in red
 	red := Color{R: 255, G: 0, B: 0}
 	fmt.Println("\n  Demo: Countdown 10 to 0")
 	for i := 10; i >= 0; i-- {
-		fb.Clear(black)
-		if err := DrawNumber(fb, i, 0, 1, AlignCenter, red, black); err != nil {
-			fmt.Printf("  Error drawing number: %v\n", err)
-			continue
-		}
-		if err := UpdateLeds(device, fb.Encode()); err != nil {
-			fmt.Printf("  Error updating LEDs: %v\n", err)
-			continue
-		}
-		time.Sleep(500 * time.Millisecond)
+		showDemoNumber(device, fb, i, 1, AlignCenter, red, black, 500*time.Millisecond)
 	}
 
 	// Demo 4: Alignment showcase
@@ -97,21 +69,34 @@ func demoDigitDisplay(device *DeviceInfo) {
 	fmt.Println("\n  Demo: Alignment (42 left/center/right)")
 	alignments := []Alignment{AlignLeft, AlignCenter, AlignRight}
 	for _, align := range alignments {
-		fb.Clear(black)
-		if err := DrawNumber(fb, 42, 0, 1, align, white, black); err != nil {
-			fmt.Printf("  Error drawing number: %v\n", err)
-			continue
-		}
-		if err := UpdateLeds(device, fb.Encode()); err != nil {
-			fmt.Printf("  Error updating LEDs: %v\n", err)
-			continue
-		}
-		time.Sleep(1 * time.Second)
+		showDemoNumber(device, fb, 42, 1, align, white, black, 1*time.Second)
 	}
 
 	fmt.Println("\n  Digit display demo complete!")
 }
 
+// showDemoNumber draws number on fb, sends it to the device and holds it for
+// the given duration. Errors are printed and skip the hold.
+func showDemoNumber(
+	device *DeviceInfo,
+	fb *Framebuffer,
+	number, spacing int,
+	alignment Alignment,
+	color, background Color,
+	hold time.Duration,
+) {
+	fb.Clear(background)
+	if err := DrawNumber(fb, number, 0, spacing, alignment, color, background); err != nil {
+		fmt.Printf("  Error drawing number: %v\n", err)
+		return
+	}
+	if err := UpdateFramebuffer(device, fb); err != nil {
+		fmt.Printf("  Error updating LEDs: %v\n", err)
+		return
+	}
+	time.Sleep(hold)
+}
+
 // ==========================================
 // Christmas Tree Animation System
 // ==========================================
@@ -182,17 +167,20 @@ func demoChristmasTree(device *DeviceInfo) {
 	fmt.Println("  =============================")
 
 	animator := NewChristmasTreeAnimator(AnimationAll)
-	fb := NewFramebuffer(20, 5)
+	fb := NewMatrixFramebuffer()
 
 	for {
 		// Update animation state
 		animator.Update()
 
 		// Render tree
-		drawChristmasTree(fb, &animator)
+		if err := drawChristmasTree(fb, &animator); err != nil {
+			fmt.Printf("  Error drawing tree: %v\n", err)
+			return
+		}
 
 		// Send to device
-		err := UpdateLeds(device, fb.Encode())
+		err := UpdateFramebuffer(device, fb)
 		if err != nil {
 			fmt.Printf("  Error updating LEDs: %v\n", err)
 			return
@@ -289,12 +277,7 @@ func (a *ChristmasTreeAnimator) updatePulse() {
 	brightness := (math.Sin(phase) + 1) / 2
 
 	for i := range a.Lights {
-		base := a.Lights[i].BaseColor
-		a.Lights[i].Color = Color{
-			R: uint8(float64(base.R) * brightness),
-			G: uint8(float64(base.G) * brightness),
-			B: uint8(float64(base.B) * brightness),
-		}
+		a.Lights[i].Color = a.Lights[i].BaseColor.DimPerceptual(brightness)
 		a.Lights[i].On = true
 	}
 }
@@ -317,8 +300,10 @@ func (a *ChristmasTreeAnimator) updateSparkle() {
 	}
 }
 
-// drawChristmasTree renders the complete tree to the framebuffer
-func drawChristmasTree(fb *Framebuffer, animator *ChristmasTreeAnimator) {
+// drawChristmasTree renders the complete tree to the framebuffer. The tree
+// needs a framebuffer of at least 14x5; on a smaller one drawing stops at the
+// first pixel out of bounds and the error is returned.
+func drawChristmasTree(fb *Framebuffer, animator *ChristmasTreeAnimator) error {
 	// 1. Clear to black
 	black := Color{R: 0, G: 0, B: 0}
 	fb.Clear(black)
@@ -327,7 +312,7 @@ func drawChristmasTree(fb *Framebuffer, animator *ChristmasTreeAnimator) {
 	for y, xCoords := range treePixels {
 		for _, x := range xCoords {
 			if err := fb.SetPixel(x, y, TreeGreen); err != nil {
-				return
+				return fmt.Errorf("failed to draw foliage: %w", err)
 			}
 		}
 	}
@@ -335,22 +320,22 @@ func drawChristmasTree(fb *Framebuffer, animator *ChristmasTreeAnimator) {
 	// 3. Draw trunk (row 4, pixels 9-11)
 	for x := 9; x <= 11; x++ {
 		if err := fb.SetPixel(x, 4, TrunkBrown); err != nil {
-			return
+			return fmt.Errorf("failed to draw trunk: %w", err)
 		}
 	}
 
 	// 4. Draw lights (overlay on tree)
 	for _, light := range animator.Lights {
+		color := LightOff
 		if light.On {
-			if err := fb.SetPixel(light.X, light.Y, light.Color); err != nil {
-				return
-			}
-		} else {
-			if err := fb.SetPixel(light.X, light.Y, LightOff); err != nil {
-				return
-			}
+			color = light.Color
+		}
+		if err := fb.SetPixel(light.X, light.Y, color); err != nil {
+			return fmt.Errorf("failed to draw light: %w", err)
 		}
 	}
+
+	return nil
 }
 
 // Matrix LED Control Functions - Patterns and Animations
@@ -360,19 +345,9 @@ func drawChristmasTree(fb *Framebuffer, animator *ChristmasTreeAnimator) {
 // numLEDs specifies the total number of LEDs (100 for a 20x5 matrix).
 // Returns a string of length numLEDs * 4 characters.
 func createSolidColor(r, g, b uint8, numLEDs int) string {
-	// Encode single pixel
-	encoded := encodeRGBColor(r, g, b)
-
-	// Use strings.Builder for efficient concatenation
-	var builder strings.Builder
-	builder.Grow(numLEDs * 4) // Pre-allocate capacity
-
-	// Repeat for all LEDs
-	for range numLEDs {
-		builder.WriteString(encoded)
-	}
-
-	return builder.String()
+	fb := newLEDStrip(numLEDs)
+	fb.FillSolid(Color{R: r, G: g, B: b})
+	return fb.Encode()
 }
 
 // createCheckerboard creates a checkerboard pattern alternating red and blue.
@@ -380,22 +355,9 @@ func createSolidColor(r, g, b uint8, numLEDs int) string {
 // For a 20x5 matrix (100 LEDs), this creates an alternating pattern across all LEDs.
 // Returns base64-encoded RGB data for numLEDs.
 func createCheckerboard(numLEDs int) string {
-	// Pre-encode colors
-	red := encodeRGBColor(255, 0, 0)
-	blue := encodeRGBColor(0, 0, 255)
-
-	var builder strings.Builder
-	builder.Grow(numLEDs * 4)
-
-	for i := range numLEDs {
-		if i%2 == 0 {
-			builder.WriteString(red)
-		} else {
-			builder.WriteString(blue)
-		}
-	}
-
-	return builder.String()
+	fb := newLEDStrip(numLEDs)
+	fb.FillCheckerboard(Color{R: 255}, Color{B: 255})
+	return fb.Encode()
 }
 
 // createGradient creates a rainbow gradient across all LEDs.
@@ -403,45 +365,15 @@ func createCheckerboard(numLEDs int) string {
 // Each LED gets a color based on its position, creating a smooth rainbow effect.
 // For a 20x5 matrix, the gradient flows across all 100 LEDs in row-major order.
 func createGradient(numLEDs int) string {
-	var builder strings.Builder
-	builder.Grow(numLEDs * 4)
-
-	for i := range numLEDs {
-		// Calculate position in gradient (0.0 to 1.0)
-		pos := float64(i) / float64(numLEDs)
-
-		var r, g, b uint8
-
-		// Rainbow gradient using piecewise linear interpolation
-		switch {
-		case pos < 0.167: // Red to Yellow
-			r = 255
-			g = uint8(pos / 0.167 * 255)
-			b = 0
-		case pos < 0.333: // Yellow to Green
-			r = uint8((0.333 - pos) / 0.166 * 255)
-			g = 255
-			b = 0
-		case pos < 0.5: // Green to Cyan
-			r = 0
-			g = 255
-			b = uint8((pos - 0.333) / 0.167 * 255)
-		case pos < 0.667: // Cyan to Blue
-			r = 0
-			g = uint8((0.667 - pos) / 0.167 * 255)
-			b = 255
-		case pos < 0.833: // Blue to Magenta
-			r = uint8((pos - 0.667) / 0.166 * 255)
-			g = 0
-			b = 255
-		default: // Magenta to Red
-			r = 255
-			g = 0
-			b = uint8((1.0 - pos) / 0.167 * 255)
-		}
-
-		builder.WriteString(encodeRGBColor(r, g, b))
-	}
+	fb := newLEDStrip(numLEDs)
+	fb.FillRainbow()
+	return fb.Encode()
+}
 
-	return builder.String()
+// newLEDStrip returns a single-row framebuffer of numLEDs pixels encoded in
+// LED order, so patterns run across the LEDs in row-major order.
+func newLEDStrip(numLEDs int) *Framebuffer {
+	fb := NewFramebuffer(numLEDs, 1)
+	fb.ReverseX = false
+	return fb
 }
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+// DeviceConn keeps a connection to a device open across commands, so callers
+// sending many commands, such as animations, do not pay a dial for each one.
+// A reader goroutine matches responses to requests by ID, so commands sent
+// without waiting for a response never leave unread data behind. When the
+// connection drops, the next command reconnects. State notifications pushed
+// by the device are available from Notifications.
+type DeviceConn struct {
+	device *DeviceInfo
+	dial   func(ctx context.Context) (net.Conn, error)
+
+	mu            sync.Mutex
+	conn          net.Conn
+	closed        chan struct{}
+	pending       map[int]chan *CommandResponse
+	nextID        int
+	notifications chan map[string]string
+	shutdown      bool
+	// rejection is the last error the device answered to a command sent
+	// without waiting for its response.
+	rejection error
+}
+
+// notificationBuffer is how many notifications are held for a slow reader
+// before further ones are dropped.
+const notificationBuffer = 16
+
+var errDeviceConnClosed = errors.New("device connection closed")
+
+func NewDeviceConn(device *DeviceInfo) *DeviceConn {
+	return &DeviceConn{
+		device: device,
+		dial: func(ctx context.Context) (net.Conn, error) {
+			return dialDevice(ctx, device)
+		},
+		pending: make(map[int]chan *CommandResponse),
+	}
+}
+
+// Connect opens the connection unless it is already open. Commands connect on
+// demand, so this is only needed to receive notifications before the first
+// command.
+func (c *DeviceConn) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shutdown {
+		return errDeviceConnClosed
+	}
+	if c.conn != nil {
+		return nil
+	}
+	return c.connect(ctx)
+}
+
+// Notifications returns a channel delivering the properties of every state
+// notification the device pushes, such as {"power": "off"} after someone
+// switches it off with the app. Notifications differ from command responses
+// by carrying no ID. The channel survives reconnects and is closed by Close;
+// notifications arriving while it is full are dropped.
+func (c *DeviceConn) Notifications() <-chan map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.notifications == nil {
+		c.notifications = make(chan map[string]string, notificationBuffer)
+		if c.shutdown {
+			close(c.notifications)
+		}
+	}
+	return c.notifications
+}
+
+// Send sends a command and waits for its response.
+func (c *DeviceConn) Send(ctx context.Context, method string, params []any) (*CommandResponse, error) {
+	responses := make(chan *CommandResponse, 1)
+	id, closed, err := c.write(ctx, method, params, responses)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(time.Until(commandDeadline(ctx)))
+	defer timer.Stop()
+
+	select {
+	case response := <-responses:
+		return response, responseError(response)
+	case <-closed:
+		return nil, errors.New("failed to read response: connection closed")
+	case <-timer.C:
+		return nil, errors.New("failed to read response: timed out")
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to read response: %w", ctx.Err())
+	}
+}
+
+// SendNoResponse sends a command without waiting for the device to answer.
+func (c *DeviceConn) SendNoResponse(ctx context.Context, method string, params []any) error {
+	_, _, err := c.write(ctx, method, params, nil)
+	return err
+}
+
+// UpdateLeds is the UpdateLeds command sent over the kept-open connection.
+func (c *DeviceConn) UpdateLeds(ctx context.Context, rgbData string) error {
+	if err := validateLedPayload(rgbData); err != nil {
+		return err
+	}
+	unlock := lockLedUpdates(c.device.Location)
+	defer unlock()
+	if err := c.SendNoResponse(ctx, "update_leds", []any{rgbData}); err != nil {
+		return fmt.Errorf("failed to update LEDs: %w", err)
+	}
+	return nil
+}
+
+// TakeRejection returns the last error the device answered to a command
+// sent with SendNoResponse, such as an exceeded quota for an update_leds,
+// and clears it. It returns nil if no command was rejected since the last
+// call.
+func (c *DeviceConn) TakeRejection() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.rejection
+	c.rejection = nil
+	return err
+}
+
+// UpdateFramebuffer is UpdateFramebuffer sent over the kept-open connection.
+func (c *DeviceConn) UpdateFramebuffer(ctx context.Context, fb *Framebuffer) error {
+	return c.UpdateLeds(ctx, fb.Encode())
+}
+
+// Close closes the underlying connection, if any, and the notifications
+// channel. The DeviceConn cannot be used afterwards.
+func (c *DeviceConn) Close() error {
+	c.mu.Lock()
+	conn := c.conn
+	c.conn = nil
+	if !c.shutdown && c.notifications != nil {
+		close(c.notifications)
+	}
+	c.shutdown = true
+	c.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+// write sends the command, connecting first if needed. If the write fails on
+// a connection that was reused, the device has likely dropped it, so the
+// command is retried once on a fresh connection. When responses is not nil
+// it is registered to receive the response.
+func (c *DeviceConn) write(
+	ctx context.Context,
+	method string,
+	params []any,
+	responses chan *CommandResponse,
+) (int, <-chan struct{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shutdown {
+		return 0, nil, errDeviceConnClosed
+	}
+
+	for attempt := 0; ; attempt++ {
+		reused := c.conn != nil
+		if !reused {
+			if err := c.connect(ctx); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		c.nextID++
+		id := c.nextID
+		cmdJSON, err := json.Marshal(CommandRequest{ID: id, Method: method, Params: params})
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to encode command: %w", err)
+		}
+
+		if responses != nil {
+			c.pending[id] = responses
+		}
+		writeErr := c.conn.SetWriteDeadline(commandDeadline(ctx))
+		if writeErr == nil {
+			_, writeErr = c.conn.Write(append(cmdJSON, '\r', '\n'))
+		}
+		if writeErr == nil {
+			return id, c.closed, nil
+		}
+
+		delete(c.pending, id)
+		c.conn.Close()
+		c.conn = nil
+		if !reused || attempt > 0 {
+			return 0, nil, fmt.Errorf("failed to send command: %w", writeErr)
+		}
+	}
+}
+
+// connect dials the device and starts reading responses. c.mu must be held.
+func (c *DeviceConn) connect(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	c.attach(conn)
+	return nil
+}
+
+// attach makes conn the current connection and starts reading responses from
+// it. c.mu must be held.
+func (c *DeviceConn) attach(conn net.Conn) {
+	closed := make(chan struct{})
+	c.conn = conn
+	c.closed = closed
+	go c.readLoop(conn, closed)
+}
+
+// readLoop delivers responses read from conn to the commands waiting for
+// them and notifications to the notifications channel until conn fails or
+// is closed. Of the responses nobody waits for, errors are kept for
+// TakeRejection and the rest discarded.
+func (c *DeviceConn) readLoop(conn net.Conn, closed chan struct{}) {
+	defer close(closed)
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil {
+			c.mu.Lock()
+			if c.conn == conn {
+				c.conn.Close()
+				c.conn = nil
+			}
+			c.mu.Unlock()
+			return
+		}
+
+		if props, ok := parseNotification(line); ok {
+			c.notify(props)
+			continue
+		}
+
+		var response CommandResponse
+		if err := json.Unmarshal(line, &response); err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		responses, waiting := c.pending[response.ID]
+		delete(c.pending, response.ID)
+		if !waiting {
+			if err := responseError(&response); err != nil {
+				c.rejection = err
+			}
+		}
+		c.mu.Unlock()
+
+		if waiting {
+			responses <- &response
+		}
+	}
+}
+
+func (c *DeviceConn) notify(props map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.notifications == nil || c.shutdown {
+		return
+	}
+	select {
+	case c.notifications <- props:
+	default:
+		slog.Warn("Dropped device notification", "device", c.device.Location, "props", props)
+	}
+}
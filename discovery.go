@@ -2,11 +2,17 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"net"
+	"strconv"
 	"strings"
 	"time"
+
+	"golang.org/x/net/ipv4"
 )
 
 type DeviceInfo struct {
@@ -23,6 +29,58 @@ type DeviceInfo struct {
 	Hue       string
 	Sat       string
 	Name      string
+	// IDConflict is set when another device at a different location reports
+	// the same ID, which some firmware versions do by mistake.
+	IDConflict bool
+}
+
+// The accessors below parse the properties a device reported in its
+// discovery answer. Each returns an error when the property is missing or
+// malformed, e.g. for a device that was not discovered.
+
+// PoweredOn reports whether the device was switched on.
+func (d *DeviceInfo) PoweredOn() (bool, error) {
+	return parsePowerState(d.Power)
+}
+
+// BrightnessInt returns the brightness in percent.
+func (d *DeviceInfo) BrightnessInt() (int, error) {
+	return parseBrightness(d.Bright)
+}
+
+// RGBColor decodes the RGB property, a packed 0xRRGGBB integer.
+func (d *DeviceInfo) RGBColor() (Color, error) {
+	rgb, err := parseIntProp("rgb", d.RGB, 0, 0xFFFFFF)
+	if err != nil {
+		return Color{}, err
+	}
+	return Color{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb)}, nil
+}
+
+// CTInt returns the color temperature in Kelvin.
+func (d *DeviceInfo) CTInt() (int, error) {
+	return parseIntProp("color temperature", d.CT, 0, math.MaxInt)
+}
+
+// HueInt returns the hue in degrees (0–359).
+func (d *DeviceInfo) HueInt() (int, error) {
+	return parseIntProp("hue", d.Hue, 0, 359)
+}
+
+// SatInt returns the saturation in percent.
+func (d *DeviceInfo) SatInt() (int, error) {
+	return parseIntProp("saturation", d.Sat, 0, 100)
+}
+
+func parseIntProp(name, value string, lowest, highest int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected %s %q: %w", name, value, err)
+	}
+	if v < lowest || v > highest {
+		return 0, fmt.Errorf("%s %d out of range", name, v)
+	}
+	return v, nil
 }
 
 const (
@@ -34,57 +92,265 @@ const (
 		"\r\n"
 )
 
-func DiscoverDevices() ([]*DeviceInfo, error) {
+// DiscoveryTimeout is how long discovery waits for devices to answer.
+var DiscoveryTimeout = 3 * time.Second
+
+// DiscoveryInterface selects the network interface discovery searches on, by
+// name (e.g. "wlan0") or by one of its IPv4 addresses. When empty the system
+// picks the interface, which on hosts with VPN or container networks is often
+// the wrong one.
+var DiscoveryInterface string
+
+// resolveDiscoveryInterface returns the interface matching nameOrIP and the
+// IPv4 address discovery should bind to on it.
+func resolveDiscoveryInterface(nameOrIP string) (*net.Interface, net.IP, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	wantIP := net.ParseIP(nameOrIP)
+	for _, iface := range interfaces {
+		addrs, addrsErr := iface.Addrs()
+		if addrsErr != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() == nil {
+				continue
+			}
+			if iface.Name == nameOrIP || ipNet.IP.Equal(wantIP) {
+				return &iface, ipNet.IP.To4(), nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no network interface with IPv4 address matches %q", nameOrIP)
+}
+
+// DiscoverDevices searches the local network for CubeLite devices for
+// DiscoveryTimeout. Discovery stops early when ctx is cancelled or its
+// deadline passes.
+func DiscoverDevices(ctx context.Context) ([]*DeviceInfo, error) {
+	return DiscoverDevicesContext(ctx, DiscoveryTimeout)
+}
+
+// DiscoverDevicesContext is like DiscoverDevices but listens for answers for
+// timeout instead of DiscoveryTimeout.
+func DiscoverDevicesContext(ctx context.Context, timeout time.Duration) ([]*DeviceInfo, error) {
+	searchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stream, err := discoverDevicesStream(searchCtx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*DeviceInfo, 0)
+	for device := range stream {
+		devices = append(devices, device)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("discovery interrupted: %w", ctxErr)
+	}
+
+	markIDConflicts(devices)
+	return devices, nil
+}
+
+// ErrDeviceNotFound is returned when no device matching a lookup answered
+// discovery.
+var ErrDeviceNotFound = errors.New("device not found")
+
+// DiscoverDeviceByID searches for the device with the given ID for up to
+// timeout and returns it as soon as it answers, without waiting for the rest
+// of the network.
+func DiscoverDeviceByID(ctx context.Context, id string, timeout time.Duration) (*DeviceInfo, error) {
+	searchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stream, err := discoverDevicesStream(searchCtx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for device := range stream {
+		if device.ID == id {
+			return device, nil
+		}
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("discovery interrupted: %w", ctxErr)
+	}
+	return nil, fmt.Errorf("%w: no device with ID %q", ErrDeviceNotFound, id)
+}
+
+// DiscoverDeviceByName searches for the device with the given name for
+// timeout. Names are set by users and need not be unique, so the whole
+// search runs and more than one match is an error.
+func DiscoverDeviceByName(ctx context.Context, name string, timeout time.Duration) (*DeviceInfo, error) {
+	devices, err := DiscoverDevicesContext(ctx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *DeviceInfo
+	for _, device := range devices {
+		if device.Name != name {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("multiple devices are named %q: %s and %s", name, match.Location, device.Location)
+		}
+		match = device
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: no device named %q", ErrDeviceNotFound, name)
+	}
+	return match, nil
+}
+
+// markIDConflicts flags every device that shares its ID with a device at a
+// different location, so both stay visible instead of being mistaken for one.
+func markIDConflicts(devices []*DeviceInfo) {
+	byID := make(map[string][]*DeviceInfo)
+	for _, device := range devices {
+		if device.ID != "" {
+			byID[device.ID] = append(byID[device.ID], device)
+		}
+	}
+
+	for id, group := range byID {
+		if len(group) < 2 {
+			continue
+		}
+		locations := make([]string, len(group))
+		for i, device := range group {
+			device.IDConflict = true
+			locations[i] = device.Location
+		}
+		slog.Warn("Multiple devices report the same ID", "id", id, "locations", locations)
+	}
+}
+
+// DiscoverDevicesStream starts discovery and delivers each unique CubeLite
+// device on the returned channel as soon as it answers. The channel is closed
+// once DiscoveryTimeout has passed or ctx is done.
+// A device reporting the ID of one already delivered from another location
+// is flagged with IDConflict; the earlier device is left as sent.
+func DiscoverDevicesStream(ctx context.Context) (<-chan *DeviceInfo, error) {
+	return discoverDevicesStream(ctx, DiscoveryTimeout)
+}
+
+// discoverDevicesStream is DiscoverDevicesStream with the time discovery
+// runs for given explicitly. The limit covers the whole search, so answers
+// repeated by the network cannot keep it open.
+func discoverDevicesStream(ctx context.Context, timeout time.Duration) (<-chan *DeviceInfo, error) {
 	addr, err := net.ResolveUDPAddr("udp4", multicastAddr)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving address: %w", err)
 	}
 
-	conn, listenErr := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero, Port: 0})
+	var iface *net.Interface
+	localIP := net.IPv4zero
+	if DiscoveryInterface != "" {
+		iface, localIP, err = resolveDiscoveryInterface(DiscoveryInterface)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, listenErr := net.ListenUDP("udp4", &net.UDPAddr{IP: localIP, Port: 0})
 	if listenErr != nil {
 		return nil, fmt.Errorf("error creating UDP connection: %w", listenErr)
 	}
-	defer conn.Close()
+
+	if iface != nil {
+		if ifaceErr := ipv4.NewPacketConn(conn).SetMulticastInterface(iface); ifaceErr != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to use interface %s for discovery: %w", iface.Name, ifaceErr)
+		}
+	}
 
 	if _, writeErr := conn.WriteToUDP([]byte(searchMessage), addr); writeErr != nil {
+		conn.Close()
 		return nil, fmt.Errorf("error sending search request: %w", writeErr)
 	}
 
-	if deadlineErr := conn.SetReadDeadline(time.Now().Add(3 * time.Second)); deadlineErr != nil {
-		return nil, fmt.Errorf("failed to set read deadline: %w", deadlineErr)
-	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	devices := make(chan *DeviceInfo)
+	go func() {
+		defer close(devices)
+		defer conn.Close()
+		defer cancel()
 
-	buffer := make([]byte, 2048)
-	discoveredDevices := make(map[string]*DeviceInfo)
+		stopAfter := context.AfterFunc(ctx, func() { _ = conn.SetReadDeadline(time.Now()) })
+		defer stopAfter()
 
-	for {
-		n, _, readErr := conn.ReadFromUDP(buffer)
-		if readErr != nil {
-			var netErr net.Error
-			if errors.As(readErr, &netErr) {
-				break
+		buffer := make([]byte, 2048)
+		seen := make(map[string]bool)
+		seenIDs := make(map[string]bool)
+
+		for ctx.Err() == nil {
+			n, _, readErr := conn.ReadFromUDP(buffer)
+			if readErr != nil {
+				var netErr net.Error
+				if errors.As(readErr, &netErr) {
+					return
+				}
+				continue
 			}
-			continue
-		}
 
-		deviceInfo := parseDeviceInfo(string(buffer[:n]))
-		if discoveredDevices[deviceInfo.Location] == nil {
-			discoveredDevices[deviceInfo.Location] = deviceInfo
+			deviceInfo := parseDeviceInfo(string(buffer[:n]))
+			if !seen[deviceInfo.Location] {
+				seen[deviceInfo.Location] = true
+				if deviceInfo.Model == "CubeLite" {
+					if deviceInfo.ID != "" {
+						deviceInfo.IDConflict = seenIDs[deviceInfo.ID]
+						seenIDs[deviceInfo.ID] = true
+					}
+					select {
+					case devices <- deviceInfo:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
 		}
+	}()
+
+	return devices, nil
+}
+
+// watchInterval is how often WatchDevices repeats the search.
+const watchInterval = 30 * time.Second
 
-		if resetDeadlineErr := conn.SetReadDeadline(time.Now().Add(3 * time.Second)); resetDeadlineErr != nil {
-			return nil, fmt.Errorf("failed to set read deadline: %w", resetDeadlineErr)
+// WatchDevices keeps searching for CubeLite devices until ctx is done,
+// repeating the search every watchInterval, and calls onDevice once for each
+// device location the first time it answers. onDevice is called from the
+// WatchDevices goroutine.
+func WatchDevices(ctx context.Context, onDevice func(*DeviceInfo)) error {
+	seen := make(map[string]bool)
+	for {
+		stream, err := DiscoverDevicesStream(ctx)
+		if err != nil {
+			return err
+		}
+		for device := range stream {
+			if !seen[device.Location] {
+				seen[device.Location] = true
+				onDevice(device)
+			}
 		}
-	}
 
-	devices := make([]*DeviceInfo, 0, len(discoveredDevices))
-	for _, device := range discoveredDevices {
-		if device.Model == "CubeLite" {
-			devices = append(devices, device)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchInterval):
 		}
 	}
-
-	return devices, nil
 }
 
 func parseDeviceInfo(response string) *DeviceInfo {
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestMarkIDConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []*DeviceInfo
+		want    []bool
+	}{
+		{
+			name: "unique IDs",
+			devices: []*DeviceInfo{
+				{ID: "0x1", Location: "yeelight://192.168.1.10:55443"},
+				{ID: "0x2", Location: "yeelight://192.168.1.11:55443"},
+			},
+			want: []bool{false, false},
+		},
+		{
+			name: "shared ID at different locations",
+			devices: []*DeviceInfo{
+				{ID: "0x1", Location: "yeelight://192.168.1.10:55443"},
+				{ID: "0x2", Location: "yeelight://192.168.1.11:55443"},
+				{ID: "0x1", Location: "yeelight://192.168.1.12:55443"},
+			},
+			want: []bool{true, false, true},
+		},
+		{
+			name: "missing IDs are not conflicts",
+			devices: []*DeviceInfo{
+				{Location: "yeelight://192.168.1.10:55443"},
+				{Location: "yeelight://192.168.1.11:55443"},
+			},
+			want: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markIDConflicts(tt.devices)
+			for i, device := range tt.devices {
+				if device.IDConflict != tt.want[i] {
+					t.Errorf("device %d: IDConflict = %v, want %v", i, device.IDConflict, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -3,10 +3,18 @@ package main
 import (
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+// Dimensions of the cube's LED matrix.
+const (
+	matrixWidth  = 20
+	matrixHeight = 5
+)
+
 type Color struct {
 	R, G, B uint8
 }
@@ -15,6 +23,20 @@ type Framebuffer struct {
 	Width  int
 	Height int
 	Pixels []Color
+	// WhiteBalance, when set, corrects every pixel as it is encoded.
+	WhiteBalance *WhiteBalance
+	// Gamma is the exponent every channel is raised to as it is encoded, so
+	// that linear fades look even on the LEDs. 1.0 (the default) and 0
+	// leave values unchanged; around 2.2 suits most LEDs.
+	Gamma float64
+	// ReverseX and ReverseY flip the order in which Encode addresses
+	// columns and rows to match the device wiring. The cube addresses LEDs
+	// right-to-left, so NewFramebuffer sets ReverseX.
+	ReverseX bool
+	ReverseY bool
+	// Serpentine flips the column order of every other row, for panels
+	// wired back and forth. The first row follows ReverseX.
+	Serpentine bool
 }
 
 type Alignment int
@@ -25,16 +47,76 @@ const (
 	AlignRight
 )
 
+// TextDirection controls the order in which DrawStringDirection lays out
+// glyphs. Glyphs themselves are never mirrored or rotated.
+type TextDirection int
+
+const (
+	DirectionLTR TextDirection = iota
+	DirectionRTL
+	DirectionTTB
+)
+
 type DigitBitmap [5][5]bool
 
+// CompactDigitBitmap is a narrow 3x5 glyph, used to fit more characters on
+// the matrix.
+type CompactDigitBitmap [5][3]bool
+
+// glyphHeight is the number of rows of every font glyph.
+const glyphHeight = 5
+
+// Font is a fixed-width bitmap font. glyph returns a function reporting
+// whether a cell of the glyph for r is lit, or false if the font has no
+// glyph for r.
+type Font struct {
+	GlyphWidth int
+	glyph      func(r rune) (func(row, col int) bool, bool)
+}
+
+var (
+	// StandardFont is the default 5x5 font.
+	StandardFont = Font{
+		GlyphWidth: 5,
+		glyph: func(r rune) (func(row, col int) bool, bool) {
+			bitmap, ok := digitFont[r]
+			return func(row, col int) bool { return bitmap[row][col] }, ok
+		},
+	}
+	// CompactFont is a 3x5 font that fits five characters across the matrix.
+	CompactFont = Font{
+		GlyphWidth: 3,
+		glyph: func(r rune) (func(row, col int) bool, bool) {
+			bitmap, ok := compactDigitFont[r]
+			return func(row, col int) bool { return bitmap[row][col] }, ok
+		},
+	}
+)
+
+// NewFramebuffer returns a black framebuffer of width x height pixels set up
+// for the cube's wiring. Smaller framebuffers are fine for sprites drawn with
+// Blit, but anything sent to the device must be 20x5; NewMatrixFramebuffer
+// returns one. It panics if either dimension is not positive, since that is
+// always a programming error.
 func NewFramebuffer(width, height int) *Framebuffer {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Sprintf("invalid framebuffer size %dx%d", width, height))
+	}
 	return &Framebuffer{
-		Width:  width,
-		Height: height,
-		Pixels: make([]Color, width*height),
+		Width:    width,
+		Height:   height,
+		Pixels:   make([]Color, width*height),
+		Gamma:    1.0,
+		ReverseX: true,
 	}
 }
 
+// NewMatrixFramebuffer returns a framebuffer the size of the cube's 20x5
+// matrix.
+func NewMatrixFramebuffer() *Framebuffer {
+	return NewFramebuffer(matrixWidth, matrixHeight)
+}
+
 func (fb *Framebuffer) Clear(color Color) {
 	for i := range fb.Pixels {
 		fb.Pixels[i] = color
@@ -42,29 +124,575 @@ func (fb *Framebuffer) Clear(color Color) {
 }
 
 func (fb *Framebuffer) SetPixel(x, y int, color Color) error {
-	if x < 0 || x >= fb.Width || y < 0 || y >= fb.Height {
+	if !fb.contains(x, y) {
 		return fmt.Errorf("pixel coordinates (%d, %d) out of bounds", x, y)
 	}
 	fb.Pixels[y*fb.Width+x] = color
 	return nil
 }
 
+// BlendPixel mixes color into the pixel at (x, y) with the given opacity:
+// alpha 0 leaves the pixel unchanged and 1 is the same as SetPixel. Alpha is
+// clamped to [0, 1] and channels are rounded to the nearest value, so
+// overlays such as glows and sparkles keep the background showing through.
+func (fb *Framebuffer) BlendPixel(x, y int, color Color, alpha float64) error {
+	if !fb.contains(x, y) {
+		return fmt.Errorf("pixel coordinates (%d, %d) out of bounds", x, y)
+	}
+	if math.IsNaN(alpha) {
+		return fmt.Errorf("invalid alpha %v", alpha)
+	}
+	i := y*fb.Width + x
+	fb.Pixels[i] = lerpColor(fb.Pixels[i], color, max(0, min(1, alpha)))
+	return nil
+}
+
+// contains reports whether (x, y) lies within the framebuffer.
+func (fb *Framebuffer) contains(x, y int) bool {
+	return x >= 0 && x < fb.Width && y >= 0 && y < fb.Height
+}
+
+// Clone returns a deep copy of the framebuffer, including its encoding
+// settings.
+func (fb *Framebuffer) Clone() *Framebuffer {
+	clone := *fb
+	clone.Pixels = slices.Clone(fb.Pixels)
+	if fb.WhiteBalance != nil {
+		wb := *fb.WhiteBalance
+		clone.WhiteBalance = &wb
+	}
+	return &clone
+}
+
+// Equal reports whether both framebuffers have the same dimensions and pixels.
+// Encoding settings are not compared.
+func (fb *Framebuffer) Equal(other *Framebuffer) bool {
+	return fb.Width == other.Width && fb.Height == other.Height && slices.Equal(fb.Pixels, other.Pixels)
+}
+
+// Blit copies the pixels of src into fb with src's top-left corner at
+// (x, y). Pixels of src equal to transparent, when it is not nil, are
+// skipped so sprites can be drawn over a background. Parts of src falling
+// outside fb are clipped. Encoding settings of both are left untouched.
+func (fb *Framebuffer) Blit(src *Framebuffer, x, y int, transparent *Color) {
+	for sy := max(0, -y); sy < min(src.Height, fb.Height-y); sy++ {
+		for sx := max(0, -x); sx < min(src.Width, fb.Width-x); sx++ {
+			c := src.Pixels[sy*src.Width+sx]
+			if transparent != nil && c == *transparent {
+				continue
+			}
+			fb.Pixels[(y+sy)*fb.Width+x+sx] = c
+		}
+	}
+}
+
 func (fb *Framebuffer) GetPixel(x, y int) (Color, error) {
-	if x < 0 || x >= fb.Width || y < 0 || y >= fb.Height {
+	if !fb.contains(x, y) {
 		return Color{}, fmt.Errorf("pixel coordinates (%d, %d) out of bounds", x, y)
 	}
 	return fb.Pixels[y*fb.Width+x], nil
 }
 
+// String renders the pixels as rows of ANSI truecolor blocks, so
+// fmt.Println(fb) shows the matrix in a terminal. Each pixel is two
+// characters wide to look roughly square. Encoding settings are not applied.
+func (fb *Framebuffer) String() string {
+	var sb strings.Builder
+	for y := range fb.Height {
+		for x := range fb.Width {
+			c := fb.Pixels[y*fb.Width+x]
+			fmt.Fprintf(&sb, "\x1b[38;2;%d;%d;%dm\u2588\u2588", c.R, c.G, c.B)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
+
+// ASCII renders the pixels as rows of '#' for lit and ' ' for black pixels,
+// for output that cannot show colors.
+func (fb *Framebuffer) ASCII() string {
+	var sb strings.Builder
+	for y := range fb.Height {
+		for x := range fb.Width {
+			if fb.Pixels[y*fb.Width+x] == (Color{}) {
+				sb.WriteByte(' ')
+			} else {
+				sb.WriteByte('#')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// FlipHorizontal mirrors the pixels left to right in place. Together with
+// FlipVertical and Rotate180 it corrects the orientation of a cube mounted
+// sideways or upside down without redrawing content; unlike ReverseX and
+// ReverseY, which describe the wiring, these change Pixels themselves.
+func (fb *Framebuffer) FlipHorizontal() {
+	for y := range fb.Height {
+		slices.Reverse(fb.Pixels[y*fb.Width : (y+1)*fb.Width])
+	}
+}
+
+// FlipVertical mirrors the pixels top to bottom in place.
+func (fb *Framebuffer) FlipVertical() {
+	for top, bottom := 0, fb.Height-1; top < bottom; top, bottom = top+1, bottom-1 {
+		topRow := fb.Pixels[top*fb.Width : (top+1)*fb.Width]
+		bottomRow := fb.Pixels[bottom*fb.Width : (bottom+1)*fb.Width]
+		for x := range fb.Width {
+			topRow[x], bottomRow[x] = bottomRow[x], topRow[x]
+		}
+	}
+}
+
+// Rotate180 turns the pixels upside down in place, the same as flipping
+// both horizontally and vertically.
+func (fb *Framebuffer) Rotate180() {
+	slices.Reverse(fb.Pixels)
+}
+
+// Shift moves every pixel dx columns right and dy rows down; negative values
+// move left and up. Pixels shifted off an edge are lost and the uncovered
+// area is filled with fill. Calling Shift(-1, 0, background) each tick
+// scrolls the content across the matrix.
+func (fb *Framebuffer) Shift(dx, dy int, fill Color) {
+	fb.shift(dx, dy, func(x, y int) Color {
+		if !fb.contains(x, y) {
+			return fill
+		}
+		return fb.Pixels[y*fb.Width+x]
+	})
+}
+
+// ShiftWrap is Shift on a torus: pixels shifted off one edge reappear on the
+// opposite edge, so repeated calls scroll content around endlessly.
+func (fb *Framebuffer) ShiftWrap(dx, dy int) {
+	if fb.Width == 0 || fb.Height == 0 {
+		return
+	}
+	fb.shift(dx, dy, func(x, y int) Color {
+		x = ((x % fb.Width) + fb.Width) % fb.Width
+		y = ((y % fb.Height) + fb.Height) % fb.Height
+		return fb.Pixels[y*fb.Width+x]
+	})
+}
+
+// shift replaces every pixel (x, y) with source(x-dx, y-dy), reading from
+// the pixels as they were before the shift.
+func (fb *Framebuffer) shift(dx, dy int, source func(x, y int) Color) {
+	shifted := make([]Color, len(fb.Pixels))
+	for y := range fb.Height {
+		for x := range fb.Width {
+			shifted[y*fb.Width+x] = source(x-dx, y-dy)
+		}
+	}
+	copy(fb.Pixels, shifted)
+}
+
+// FillGradient fills the framebuffer with a linear gradient from start to
+// end, across the width when horizontal is true and down the height
+// otherwise. The first column (or row) is start and the last is end.
+func (fb *Framebuffer) FillGradient(start, end Color, horizontal bool) {
+	steps := fb.Height
+	if horizontal {
+		steps = fb.Width
+	}
+
+	for y := range fb.Height {
+		for x := range fb.Width {
+			step := y
+			if horizontal {
+				step = x
+			}
+			t := 1.0
+			if steps > 1 {
+				t = float64(step) / float64(steps-1)
+			}
+			fb.Pixels[y*fb.Width+x] = lerpColor(start, end, t)
+		}
+	}
+}
+
+// FillSolid sets every pixel to color. It is Clear under the name of the
+// other pattern fills.
+func (fb *Framebuffer) FillSolid(color Color) {
+	fb.Clear(color)
+}
+
+// FillCheckerboard fills the framebuffer with a checkerboard of single
+// pixels, starting with a in the top-left corner.
+func (fb *Framebuffer) FillCheckerboard(a, b Color) {
+	for y := range fb.Height {
+		for x := range fb.Width {
+			color := a
+			if (x+y)%2 == 1 {
+				color = b
+			}
+			fb.Pixels[y*fb.Width+x] = color
+		}
+	}
+}
+
+// FillRainbow fills every row with a rainbow running across the width,
+// through red, yellow, green, cyan, blue and magenta back towards red.
+func (fb *Framebuffer) FillRainbow() {
+	for y := range fb.Height {
+		for x := range fb.Width {
+			fb.Pixels[y*fb.Width+x] = rainbowColor(float64(x) / float64(fb.Width))
+		}
+	}
+}
+
+// rainbowColor returns the color at pos (0.0 to 1.0) of a rainbow, using
+// piecewise linear interpolation between the primary and secondary colors.
+func rainbowColor(pos float64) Color {
+	switch {
+	case pos < 0.167: // Red to Yellow
+		return Color{R: 255, G: uint8(pos / 0.167 * 255)}
+	case pos < 0.333: // Yellow to Green
+		return Color{R: uint8((0.333 - pos) / 0.166 * 255), G: 255}
+	case pos < 0.5: // Green to Cyan
+		return Color{G: 255, B: uint8((pos - 0.333) / 0.167 * 255)}
+	case pos < 0.667: // Cyan to Blue
+		return Color{G: uint8((0.667 - pos) / 0.167 * 255), B: 255}
+	case pos < 0.833: // Blue to Magenta
+		return Color{R: uint8((pos - 0.667) / 0.166 * 255), B: 255}
+	default: // Magenta to Red
+		return Color{R: 255, B: uint8((1.0 - pos) / 0.167 * 255)}
+	}
+}
+
+// FillHeatmap colors the framebuffer from values, each normalized from the
+// range [low, high] to [0, 1] and mapped through palette, or BlueRedPalette
+// when palette is nil. With one value per column each value fills its whole
+// column; with one value per pixel, in row-major order, each fills its own
+// pixel. Values outside the range are clamped and NaN values are drawn
+// black.
+func (fb *Framebuffer) FillHeatmap(values []float64, low, high float64, palette func(float64) Color) error {
+	if !(high > low) {
+		return fmt.Errorf("invalid heatmap range [%v, %v]", low, high)
+	}
+	if palette == nil {
+		palette = BlueRedPalette
+	}
+
+	var cell func(x, y int) float64
+	switch len(values) {
+	case len(fb.Pixels):
+		cell = func(x, y int) float64 { return values[y*fb.Width+x] }
+	case fb.Width:
+		cell = func(x, _ int) float64 { return values[x] }
+	default:
+		return fmt.Errorf("heatmap needs %d or %d values, got %d", fb.Width, len(fb.Pixels), len(values))
+	}
+
+	for y := range fb.Height {
+		for x := range fb.Width {
+			v := cell(x, y)
+			if math.IsNaN(v) {
+				fb.Pixels[y*fb.Width+x] = Color{}
+				continue
+			}
+			fb.Pixels[y*fb.Width+x] = palette(max(0, min(1, (v-low)/(high-low))))
+		}
+	}
+	return nil
+}
+
+// BlueRedPalette maps t in [0, 1] from cold blue to hot red, the default
+// palette of FillHeatmap.
+func BlueRedPalette(t float64) Color {
+	return lerpColor(Color{B: 255}, Color{R: 255}, t)
+}
+
+// lerpColor linearly interpolates between from and to, t in [0, 1].
+func lerpColor(from, to Color, t float64) Color {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return Color{R: lerp(from.R, to.R), G: lerp(from.G, to.G), B: lerp(from.B, to.B)}
+}
+
+// DrawLine draws a straight line from (x0, y0) to (x1, y1) inclusive using
+// Bresenham's algorithm. Both endpoints must lie within the framebuffer;
+// otherwise nothing is drawn and an error is returned.
+func (fb *Framebuffer) DrawLine(x0, y0, x1, y1 int, color Color) error {
+	for _, p := range [][2]int{{x0, y0}, {x1, y1}} {
+		if !fb.contains(p[0], p[1]) {
+			return fmt.Errorf("line endpoint (%d, %d) out of bounds", p[0], p[1])
+		}
+	}
+
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+
+	x, y := x0, y0
+	errTerm := dx + dy
+	for {
+		if err := fb.SetPixel(x, y, color); err != nil {
+			return fmt.Errorf("failed to set pixel: %w", err)
+		}
+		if x == x1 && y == y1 {
+			return nil
+		}
+		e2 := 2 * errTerm
+		if e2 >= dy {
+			errTerm += dy
+			x += stepX
+		}
+		if e2 <= dx {
+			errTerm += dx
+			y += stepY
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// DrawCircle draws the outline of a circle of radius r centred on (cx, cy)
+// using the midpoint circle algorithm. Radius 0 is a single pixel, 1 a
+// four-pixel diamond and 2 a twelve-pixel ring. Points outside the
+// framebuffer are clipped, so the circle may be partially off-screen.
+func (fb *Framebuffer) DrawCircle(cx, cy, r int, color Color) error {
+	if r < 0 {
+		return fmt.Errorf("invalid circle radius %d", r)
+	}
+	midpointCircle(r, func(x, y int) {
+		for _, p := range [][2]int{{x, y}, {y, x}} {
+			fb.setPixelClipped(cx+p[0], cy+p[1], color)
+			fb.setPixelClipped(cx-p[0], cy+p[1], color)
+			fb.setPixelClipped(cx+p[0], cy-p[1], color)
+			fb.setPixelClipped(cx-p[0], cy-p[1], color)
+		}
+	})
+	return nil
+}
+
+// FillCircle draws a filled circle covering the same area as DrawCircle's
+// outline and everything inside it. Points outside the framebuffer are
+// clipped.
+func (fb *Framebuffer) FillCircle(cx, cy, r int, color Color) error {
+	if r < 0 {
+		return fmt.Errorf("invalid circle radius %d", r)
+	}
+	midpointCircle(r, func(x, y int) {
+		fb.fillSpanClipped(cx-x, cx+x, cy+y, color)
+		fb.fillSpanClipped(cx-x, cx+x, cy-y, color)
+		fb.fillSpanClipped(cx-y, cx+y, cy+x, color)
+		fb.fillSpanClipped(cx-y, cx+y, cy-x, color)
+	})
+	return nil
+}
+
+// midpointCircle calls plot with every point of the first octant of a circle
+// of radius r centred on the origin, from (r, 0) until x < y.
+func midpointCircle(r int, plot func(x, y int)) {
+	x, y := r, 0
+	decision := 1 - r
+	for x >= y {
+		plot(x, y)
+		y++
+		if decision < 0 {
+			decision += 2*y + 1
+		} else {
+			x--
+			decision += 2*(y-x) + 1
+		}
+	}
+}
+
+// DrawEllipse draws the outline of an axis-aligned ellipse centred on
+// (cx, cy) with horizontal radius rx and vertical radius ry, using the
+// midpoint ellipse algorithm. Ellipses suit the wide matrix better than
+// circles, which can be at most 5 pixels across. Points outside the
+// framebuffer are clipped.
+func (fb *Framebuffer) DrawEllipse(cx, cy, rx, ry int, color Color) error {
+	if rx < 0 || ry < 0 {
+		return fmt.Errorf("invalid ellipse radii %d, %d", rx, ry)
+	}
+	midpointEllipse(rx, ry, func(x, y int) {
+		fb.setPixelClipped(cx+x, cy+y, color)
+		fb.setPixelClipped(cx-x, cy+y, color)
+		fb.setPixelClipped(cx+x, cy-y, color)
+		fb.setPixelClipped(cx-x, cy-y, color)
+	})
+	return nil
+}
+
+// FillEllipse draws a filled ellipse covering the same area as DrawEllipse's
+// outline and everything inside it. Points outside the framebuffer are
+// clipped.
+func (fb *Framebuffer) FillEllipse(cx, cy, rx, ry int, color Color) error {
+	if rx < 0 || ry < 0 {
+		return fmt.Errorf("invalid ellipse radii %d, %d", rx, ry)
+	}
+	midpointEllipse(rx, ry, func(x, y int) {
+		fb.fillSpanClipped(cx-x, cx+x, cy+y, color)
+		fb.fillSpanClipped(cx-x, cx+x, cy-y, color)
+	})
+	return nil
+}
+
+// midpointEllipse calls plot with every point of the first quadrant of an
+// ellipse with radii rx and ry centred on the origin, from (0, ry) to
+// (rx, 0).
+func midpointEllipse(rx, ry int, plot func(x, y int)) {
+	if rx == 0 || ry == 0 {
+		// Degenerate ellipses are straight lines.
+		for x := range rx + 1 {
+			plot(x, 0)
+		}
+		for y := range ry + 1 {
+			plot(0, y)
+		}
+		return
+	}
+
+	rx2, ry2 := rx*rx, ry*ry
+	x, y := 0, ry
+
+	// Region 1: the slope is shallower than -1, step x every time.
+	decision := 4*ry2 - 4*rx2*ry + rx2
+	for ry2*x < rx2*y {
+		plot(x, y)
+		if decision >= 0 {
+			y--
+			decision -= 8 * rx2 * y
+		}
+		x++
+		decision += 4 * ry2 * (2*x + 1)
+	}
+
+	// Region 2: the slope is steeper than -1, step y every time.
+	decision = ry2*(2*x+1)*(2*x+1) + 4*rx2*(y-1)*(y-1) - 4*rx2*ry2
+	for y >= 0 {
+		plot(x, y)
+		if decision <= 0 {
+			x++
+			decision += 8 * ry2 * x
+		}
+		y--
+		decision += 4 * rx2 * (1 - 2*y)
+	}
+}
+
+// setPixelClipped sets the pixel at (x, y), ignoring points outside the
+// framebuffer.
+func (fb *Framebuffer) setPixelClipped(x, y int, color Color) {
+	if fb.contains(x, y) {
+		fb.Pixels[y*fb.Width+x] = color
+	}
+}
+
+// fillSpanClipped sets the pixels of row y from x0 to x1 inclusive, ignoring
+// points outside the framebuffer.
+func (fb *Framebuffer) fillSpanClipped(x0, x1, y int, color Color) {
+	if y < 0 || y >= fb.Height {
+		return
+	}
+	for x := max(x0, 0); x <= min(x1, fb.Width-1); x++ {
+		fb.Pixels[y*fb.Width+x] = color
+	}
+}
+
+// Scale multiplies every channel of every pixel by factor, rounding to the
+// nearest value and clamping at 255. Unlike DimPerceptual it works on the
+// stored values directly, which is cheap and predictable but shifts hue at
+// low levels.
+func (fb *Framebuffer) Scale(factor float64) {
+	scale := func(v uint8) uint8 {
+		return uint8(min(255, max(0, math.Round(float64(v)*factor))))
+	}
+	for i, pixel := range fb.Pixels {
+		fb.Pixels[i] = Color{R: scale(pixel.R), G: scale(pixel.G), B: scale(pixel.B)}
+	}
+}
+
+// DimPerceptual dims every pixel by factor (0.0–1.0) in linear light: each
+// channel is decoded from sRGB, scaled and encoded back. Multiplying the
+// stored values directly is not proportional to emitted light, so low levels
+// drift in hue and look muddy; dimming in linear light keeps the channel
+// ratios the LEDs actually emit.
+func (fb *Framebuffer) DimPerceptual(factor float64) {
+	factor = max(0, min(1, factor))
+	for i, pixel := range fb.Pixels {
+		fb.Pixels[i] = pixel.DimPerceptual(factor)
+	}
+}
+
+// DimPerceptual returns c dimmed by factor (0.0–1.0) in linear light.
+func (c Color) DimPerceptual(factor float64) Color {
+	return Color{
+		R: linearToSRGB(srgbToLinear(c.R) * factor),
+		G: linearToSRGB(srgbToLinear(c.G) * factor),
+		B: linearToSRGB(srgbToLinear(c.B) * factor),
+	}
+}
+
+func srgbToLinear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(l float64) uint8 {
+	l = max(0, min(1, l))
+	var c float64
+	if l <= 0.0031308 {
+		c = l * 12.92
+	} else {
+		c = 1.055*math.Pow(l, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(c * 255))
+}
+
 // Encode converts the framebuffer to base64-encoded RGB string for UpdateLeds.
-// X-axis is reversed because hardware addresses LEDs right-to-left.
+// Columns and rows are emitted in the order set by ReverseX, ReverseY and
+// Serpentine.
+// Pixels are white-balanced and gamma-corrected on the way out; Pixels itself
+// is left untouched.
 func (fb *Framebuffer) Encode() string {
 	var builder strings.Builder
 	builder.Grow(len(fb.Pixels) * 4)
 
-	for y := range fb.Height {
-		for x := fb.Width - 1; x >= 0; x-- {
+	gamma := gammaTable(fb.Gamma)
+
+	for row := range fb.Height {
+		y := row
+		if fb.ReverseY {
+			y = fb.Height - 1 - row
+		}
+		reverse := fb.ReverseX
+		if fb.Serpentine && row%2 == 1 {
+			reverse = !reverse
+		}
+		for col := range fb.Width {
+			x := col
+			if reverse {
+				x = fb.Width - 1 - col
+			}
 			pixel := fb.Pixels[y*fb.Width+x]
+			if fb.WhiteBalance != nil {
+				pixel = fb.WhiteBalance.Apply(pixel)
+			}
+			if gamma != nil {
+				pixel = Color{R: gamma[pixel.R], G: gamma[pixel.G], B: gamma[pixel.B]}
+			}
 			builder.WriteString(encodeRGBColor(pixel.R, pixel.G, pixel.B))
 		}
 	}
@@ -72,6 +700,19 @@ func (fb *Framebuffer) Encode() string {
 	return builder.String()
 }
 
+// gammaTable maps every channel value through the given gamma, or returns nil
+// when gamma leaves values unchanged.
+func gammaTable(gamma float64) *[256]uint8 {
+	if gamma <= 0 || gamma == 1 {
+		return nil
+	}
+	var table [256]uint8
+	for v := range table {
+		table[v] = uint8(math.Round(math.Pow(float64(v)/255, gamma) * 255))
+	}
+	return &table
+}
+
 var digitFont = map[rune]DigitBitmap{
 	'0': {
 		{true, true, true, true, true},
@@ -143,22 +784,147 @@ var digitFont = map[rune]DigitBitmap{
 		{false, false, false, false, true},
 		{true, true, true, true, true},
 	},
+	':': {
+		{false, false, false, false, false},
+		{false, false, true, false, false},
+		{false, false, false, false, false},
+		{false, false, true, false, false},
+		{false, false, false, false, false},
+	},
+	'-': {
+		{false, false, false, false, false},
+		{false, false, false, false, false},
+		{false, true, true, true, false},
+		{false, false, false, false, false},
+		{false, false, false, false, false},
+	},
+	'.': {
+		{false, false, false, false, false},
+		{false, false, false, false, false},
+		{false, false, false, false, false},
+		{false, false, false, false, false},
+		{false, false, true, false, false},
+	},
 }
 
+var compactDigitFont = map[rune]CompactDigitBitmap{
+	'0': {
+		{true, true, true},
+		{true, false, true},
+		{true, false, true},
+		{true, false, true},
+		{true, true, true},
+	},
+	'1': {
+		{false, true, false},
+		{true, true, false},
+		{false, true, false},
+		{false, true, false},
+		{true, true, true},
+	},
+	'2': {
+		{true, true, true},
+		{false, false, true},
+		{true, true, true},
+		{true, false, false},
+		{true, true, true},
+	},
+	'3': {
+		{true, true, true},
+		{false, false, true},
+		{true, true, true},
+		{false, false, true},
+		{true, true, true},
+	},
+	'4': {
+		{true, false, true},
+		{true, false, true},
+		{true, true, true},
+		{false, false, true},
+		{false, false, true},
+	},
+	'5': {
+		{true, true, true},
+		{true, false, false},
+		{true, true, true},
+		{false, false, true},
+		{true, true, true},
+	},
+	'6': {
+		{true, true, true},
+		{true, false, false},
+		{true, true, true},
+		{true, false, true},
+		{true, true, true},
+	},
+	'7': {
+		{true, true, true},
+		{false, false, true},
+		{false, false, true},
+		{false, true, false},
+		{false, true, false},
+	},
+	'8': {
+		{true, true, true},
+		{true, false, true},
+		{true, true, true},
+		{true, false, true},
+		{true, true, true},
+	},
+	'9': {
+		{true, true, true},
+		{true, false, true},
+		{true, true, true},
+		{false, false, true},
+		{true, true, true},
+	},
+	':': {
+		{false, false, false},
+		{false, true, false},
+		{false, false, false},
+		{false, true, false},
+		{false, false, false},
+	},
+	'-': {
+		{false, false, false},
+		{false, false, false},
+		{true, true, true},
+		{false, false, false},
+		{false, false, false},
+	},
+	'.': {
+		{false, false, false},
+		{false, false, false},
+		{false, false, false},
+		{false, false, false},
+		{false, true, false},
+	},
+}
+
+// DrawDigit draws the StandardFont glyph for digit with its top-left corner
+// at (x, y), painting unlit cells with background. The whole glyph must fit
+// in the framebuffer; otherwise nothing is drawn and an error is returned.
 func DrawDigit(fb *Framebuffer, digit rune, x, y int, color, background Color) error {
-	bitmap, exists := digitFont[digit]
+	return drawGlyph(fb, StandardFont, digit, x, y, color, background)
+}
+
+// drawGlyph draws a glyph of font, checking bounds before drawing anything
+// so a glyph is never drawn partially. SetPixel errors are still returned
+// rather than assumed impossible.
+func drawGlyph(fb *Framebuffer, font Font, digit rune, x, y int, color, background Color) error {
+	lit, exists := font.glyph(digit)
 	if !exists {
 		return fmt.Errorf("invalid digit character: '%c'", digit)
 	}
 
-	if x+5 > fb.Width || y+5 > fb.Height || x < 0 || y < 0 {
+	if x+font.GlyphWidth > fb.Width || y+glyphHeight > fb.Height || x < 0 || y < 0 {
 		return fmt.Errorf("digit at position (%d, %d) exceeds bounds", x, y)
 	}
 
-	for row := range 5 {
-		for col := range 5 {
+	for row := range glyphHeight {
+		for col := range font.GlyphWidth {
 			pixelColor := background
-			if bitmap[row][col] {
+			if lit(row, col) {
 				pixelColor = color
 			}
 			if err := fb.SetPixel(x+col, y+row, pixelColor); err != nil {
@@ -175,34 +941,135 @@ func DrawNumber(fb *Framebuffer, number int, y, spacing int, alignment Alignment
 }
 
 func DrawString(fb *Framebuffer, str string, y, spacing int, alignment Alignment, color, background Color) error {
+	return DrawStringDirection(fb, str, y, spacing, alignment, DirectionLTR, color, background)
+}
+
+// DrawStringCompact is DrawString using the narrow CompactFont.
+func DrawStringCompact(
+	fb *Framebuffer,
+	str string,
+	y, spacing int,
+	alignment Alignment,
+	color, background Color,
+) error {
+	return drawStringFont(fb, CompactFont, str, y, spacing, alignment, DirectionLTR, color, background)
+}
+
+// DrawStringDirection draws str with glyphs advancing in the given direction.
+// For DirectionLTR and DirectionRTL, offset is the top row and alignment is
+// horizontal. For DirectionTTB, offset is the left column and alignment is
+// vertical, with AlignLeft meaning top and AlignRight meaning bottom.
+func DrawStringDirection(
+	fb *Framebuffer,
+	str string,
+	offset, spacing int,
+	alignment Alignment,
+	direction TextDirection,
+	color, background Color,
+) error {
+	return drawStringFont(fb, StandardFont, str, offset, spacing, alignment, direction, color, background)
+}
+
+func drawStringFont(
+	fb *Framebuffer,
+	font Font,
+	str string,
+	offset, spacing int,
+	alignment Alignment,
+	direction TextDirection,
+	color, background Color,
+) error {
 	if len(str) == 0 {
 		return errors.New("cannot draw empty string")
 	}
 
-	totalWidth := len(str)*5 + (len(str)-1)*spacing
-	if totalWidth > fb.Width {
-		return fmt.Errorf("string '%s' too wide: needs %d pixels", str, totalWidth)
+	var extent, glyphSize int
+	switch direction {
+	case DirectionLTR, DirectionRTL:
+		extent, glyphSize = fb.Width, font.GlyphWidth
+	case DirectionTTB:
+		extent, glyphSize = fb.Height, glyphHeight
+	default:
+		return fmt.Errorf("invalid text direction value: %d", direction)
+	}
+
+	totalLength := len(str)*glyphSize + (len(str)-1)*spacing
+	if totalLength > extent {
+		return fmt.Errorf("string '%s' too long: needs %d pixels", str, totalLength)
 	}
 
-	var startX int
+	var start int
 	switch alignment {
 	case AlignLeft:
-		startX = 0
+		start = 0
 	case AlignCenter:
-		startX = (fb.Width - totalWidth) / 2
+		start = (extent - totalLength) / 2
 	case AlignRight:
-		startX = fb.Width - totalWidth
+		start = extent - totalLength
 	default:
 		return fmt.Errorf("invalid alignment value: %d", alignment)
 	}
 
-	currentX := startX
-	for _, digit := range str {
-		if err := DrawDigit(fb, digit, currentX, y, color, background); err != nil {
+	advance := glyphSize + spacing
+	for i, digit := range []rune(str) {
+		var x, y int
+		switch direction {
+		case DirectionLTR:
+			x, y = start+i*advance, offset
+		case DirectionRTL:
+			x, y = start+totalLength-glyphSize-i*advance, offset
+		case DirectionTTB:
+			x, y = offset, start+i*advance
+		}
+		if err := drawGlyph(fb, font, digit, x, y, color, background); err != nil {
 			return fmt.Errorf("failed to draw digit '%c': %w", digit, err)
 		}
-		currentX += 5 + spacing
 	}
 
 	return nil
 }
+
+// scrollSpacing is the gap in pixels between glyphs drawn by ScrollString.
+const scrollSpacing = 1
+
+// ScrollString draws str in StandardFont with its left edge at column
+// offsetX, clipping it to the framebuffer. Any part of the text may be
+// off-screen, so callers can step offsetX each tick to scroll a message
+// across the matrix. Every visible column of the text rows is repainted,
+// with background between and beyond glyphs. Runes without a glyph are drawn
+// as blanks.
+func ScrollString(fb *Framebuffer, str string, y, offsetX int, color, background Color) {
+	runes := []rune(str)
+	advance := StandardFont.GlyphWidth + scrollSpacing
+
+	for x := range fb.Width {
+		lit := func(int) bool { return false }
+		if col := x - offsetX; col >= 0 && col/advance < len(runes) && col%advance < StandardFont.GlyphWidth {
+			if glyph, ok := StandardFont.glyph(runes[col/advance]); ok {
+				lit = func(row int) bool { return glyph(row, col%advance) }
+			}
+		}
+
+		for row := range glyphHeight {
+			if y+row < 0 || y+row >= fb.Height {
+				continue
+			}
+			pixelColor := background
+			if lit(row) {
+				pixelColor = color
+			}
+			fb.Pixels[(y+row)*fb.Width+x] = pixelColor
+		}
+	}
+}
+
+// ScrollWidth returns the width in pixels of str as drawn by ScrollString.
+// A message has scrolled fully off the left edge once offsetX is at or below
+// its negated width.
+func ScrollWidth(str string) int {
+	count := len([]rune(str))
+	if count == 0 {
+		return 0
+	}
+	return count*(StandardFont.GlyphWidth+scrollSpacing) - scrollSpacing
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+)
+
+var (
+	white = Color{R: 255, G: 255, B: 255}
+	black = Color{}
+)
+
+// glyphPosition is where a single glyph is expected to be drawn.
+type glyphPosition struct {
+	glyph rune
+	x, y  int
+}
+
+func TestDrawStringDirection(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		str           string
+		alignment     Alignment
+		direction     TextDirection
+		want          []glyphPosition
+		wantErr       bool
+	}{
+		{
+			name:  "left to right",
+			width: 20, height: 5, str: "12", alignment: AlignLeft, direction: DirectionLTR,
+			want: []glyphPosition{{'1', 0, 0}, {'2', 6, 0}},
+		},
+		{
+			name:  "right to left",
+			width: 20, height: 5, str: "12", alignment: AlignLeft, direction: DirectionRTL,
+			want: []glyphPosition{{'1', 6, 0}, {'2', 0, 0}},
+		},
+		{
+			name:  "right to left aligned right",
+			width: 20, height: 5, str: "12", alignment: AlignRight, direction: DirectionRTL,
+			want: []glyphPosition{{'1', 15, 0}, {'2', 9, 0}},
+		},
+		{
+			name:  "top to bottom",
+			width: 5, height: 11, str: "12", alignment: AlignLeft, direction: DirectionTTB,
+			want: []glyphPosition{{'1', 0, 0}, {'2', 0, 6}},
+		},
+		{
+			name:  "top to bottom aligned bottom",
+			width: 5, height: 13, str: "12", alignment: AlignRight, direction: DirectionTTB,
+			want: []glyphPosition{{'1', 0, 2}, {'2', 0, 8}},
+		},
+		{
+			name:  "too tall for top to bottom",
+			width: 20, height: 5, str: "12", alignment: AlignLeft, direction: DirectionTTB,
+			wantErr: true,
+		},
+		{
+			name:  "invalid direction",
+			width: 20, height: 5, str: "1", alignment: AlignLeft, direction: TextDirection(99),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFramebuffer(tt.width, tt.height)
+			err := DrawStringDirection(fb, tt.str, 0, 1, tt.alignment, tt.direction, white, black)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DrawStringDirection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := NewFramebuffer(tt.width, tt.height)
+			for _, pos := range tt.want {
+				if err := DrawDigit(want, pos.glyph, pos.x, pos.y, white, black); err != nil {
+					t.Fatalf("DrawDigit(%q) error = %v", pos.glyph, err)
+				}
+			}
+			if !fb.Equal(want) {
+				t.Errorf("DrawStringDirection() drew\n%s\nwant\n%s", fb.ASCII(), want.ASCII())
+			}
+		})
+	}
+}
+
+func TestDimPerceptual(t *testing.T) {
+	tests := []struct {
+		name   string
+		color  Color
+		factor float64
+		want   Color
+	}{
+		{
+			name:  "full brightness keeps the color",
+			color: Color{R: 255, G: 128, B: 7}, factor: 1, want: Color{R: 255, G: 128, B: 7},
+		},
+		{name: "zero is black", color: white, factor: 0, want: black},
+		{name: "half the light of white", color: white, factor: 0.5, want: Color{R: 188, G: 188, B: 188}},
+		{
+			name:  "half the light of mid gray",
+			color: Color{R: 128, G: 128, B: 128}, factor: 0.5, want: Color{R: 92, G: 92, B: 92},
+		},
+		{
+			name:  "factor above one is clamped",
+			color: Color{R: 10, G: 20, B: 30}, factor: 2, want: Color{R: 10, G: 20, B: 30},
+		},
+		{name: "negative factor is clamped", color: white, factor: -1, want: black},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFramebuffer(1, 1)
+			fb.Pixels[0] = tt.color
+			fb.DimPerceptual(tt.factor)
+			if fb.Pixels[0] != tt.want {
+				t.Errorf("DimPerceptual(%v) = %+v, want %+v", tt.factor, fb.Pixels[0], tt.want)
+			}
+		})
+	}
+}
+
+// decodePixels returns the colors of an Encode payload in wire order.
+func decodePixels(t *testing.T, payload string) []Color {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatalf("payload is not base64: %v", err)
+	}
+	pixels := make([]Color, len(data)/3)
+	for i := range pixels {
+		pixels[i] = Color{R: data[3*i], G: data[3*i+1], B: data[3*i+2]}
+	}
+	return pixels
+}
+
+func TestEncode(t *testing.T) {
+	// A 3x2 framebuffer with pixels numbered 1–6 in reading order, in the
+	// red channel.
+	numbered := func(fb *Framebuffer) {
+		for i := range fb.Pixels {
+			fb.Pixels[i] = Color{R: uint8(i + 1)}
+		}
+	}
+	red := func(values ...uint8) []Color {
+		colors := make([]Color, len(values))
+		for i, v := range values {
+			colors[i] = Color{R: v}
+		}
+		return colors
+	}
+
+	tests := []struct {
+		name      string
+		configure func(fb *Framebuffer)
+		want      []Color
+	}{
+		{
+			name:      "reading order",
+			configure: func(fb *Framebuffer) { fb.ReverseX = false },
+			want:      red(1, 2, 3, 4, 5, 6),
+		},
+		{
+			name:      "reversed columns",
+			configure: func(fb *Framebuffer) {},
+			want:      red(3, 2, 1, 6, 5, 4),
+		},
+		{
+			name:      "reversed rows",
+			configure: func(fb *Framebuffer) { fb.ReverseX, fb.ReverseY = false, true },
+			want:      red(4, 5, 6, 1, 2, 3),
+		},
+		{
+			name:      "serpentine",
+			configure: func(fb *Framebuffer) { fb.ReverseX, fb.Serpentine = false, true },
+			want:      red(1, 2, 3, 6, 5, 4),
+		},
+		{
+			name:      "serpentine with reversed columns",
+			configure: func(fb *Framebuffer) { fb.Serpentine = true },
+			want:      red(3, 2, 1, 4, 5, 6),
+		},
+		{
+			name: "white balance",
+			configure: func(fb *Framebuffer) {
+				fb.ReverseX = false
+				fb.WhiteBalance = &WhiteBalance{R: 2, G: 1, B: 1}
+			},
+			want: red(2, 4, 6, 8, 10, 12),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFramebuffer(3, 2)
+			numbered(fb)
+			tt.configure(fb)
+			before := slices.Clone(fb.Pixels)
+
+			if got := decodePixels(t, fb.Encode()); !slices.Equal(got, tt.want) {
+				t.Errorf("Encode() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(fb.Pixels, before) {
+				t.Error("Encode() modified the framebuffer's pixels")
+			}
+		})
+	}
+}
+
+func TestFillGradient(t *testing.T) {
+	red, blue := Color{R: 255}, Color{B: 255}
+
+	tests := []struct {
+		name          string
+		width, height int
+		horizontal    bool
+		// want are the colors of the first row when horizontal, otherwise
+		// of the first column; the other rows or columns repeat it.
+		want []Color
+	}{
+		{
+			name:  "horizontal",
+			width: 5, height: 2, horizontal: true,
+			want: []Color{red, {R: 191, B: 64}, {R: 128, B: 128}, {R: 64, B: 191}, blue},
+		},
+		{
+			name:  "vertical",
+			width: 2, height: 3, horizontal: false,
+			want: []Color{red, {R: 128, B: 128}, blue},
+		},
+		{
+			name:  "two steps are the endpoints",
+			width: 2, height: 1, horizontal: true,
+			want: []Color{red, blue},
+		},
+		{
+			name:  "a single step is the end color",
+			width: 1, height: 3, horizontal: true,
+			want: []Color{blue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFramebuffer(tt.width, tt.height)
+			fb.FillGradient(red, blue, tt.horizontal)
+
+			for y := range tt.height {
+				for x := range tt.width {
+					step := y
+					if tt.horizontal {
+						step = x
+					}
+					if got, _ := fb.GetPixel(x, y); got != tt.want[step] {
+						t.Errorf("pixel (%d, %d) = %+v, want %+v", x, y, got, tt.want[step])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFramebufferEqual(t *testing.T) {
+	filled := func(width, height int, c Color) *Framebuffer {
+		fb := NewFramebuffer(width, height)
+		fb.Clear(c)
+		return fb
+	}
+	differentSettings := filled(3, 2, white)
+	differentSettings.ReverseX = !differentSettings.ReverseX
+	differentSettings.WhiteBalance = &WhiteBalance{R: 1, G: 0.5, B: 0.5}
+	onePixelOff := filled(3, 2, white)
+	onePixelOff.Pixels[4] = black
+
+	tests := []struct {
+		name  string
+		other *Framebuffer
+		want  bool
+	}{
+		{name: "same pixels", other: filled(3, 2, white), want: true},
+		{name: "encoding settings ignored", other: differentSettings, want: true},
+		{name: "one pixel differs", other: onePixelOff, want: false},
+		{name: "transposed dimensions", other: filled(2, 3, white), want: false},
+		{name: "different size", other: filled(3, 3, white), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := filled(3, 2, white)
+			if got := fb.Equal(tt.other); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+			if got := tt.other.Equal(fb); got != tt.want {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFramebufferClone(t *testing.T) {
+	fb := NewFramebuffer(3, 2)
+	fb.Clear(white)
+	fb.WhiteBalance = &WhiteBalance{R: 1, G: 0.8, B: 0.6}
+
+	clone := fb.Clone()
+	if !clone.Equal(fb) || *clone.WhiteBalance != *fb.WhiteBalance || clone.ReverseX != fb.ReverseX {
+		t.Fatalf("Clone() = %+v, want a copy of %+v", clone, fb)
+	}
+
+	clone.Pixels[0] = black
+	clone.WhiteBalance.R = 0.5
+	if fb.Pixels[0] != white || fb.WhiteBalance.R != 1 {
+		t.Error("modifying the clone changed the original")
+	}
+}
+
+func TestBlit(t *testing.T) {
+	red, green := Color{R: 255}, Color{G: 255}
+	// sprite is 2x2: red on top, black (transparent when asked) bottom left
+	// and green bottom right.
+	sprite := NewFramebuffer(2, 2)
+	sprite.Pixels = []Color{red, red, black, green}
+
+	tests := []struct {
+		name        string
+		x, y        int
+		transparent *Color
+		// want is the 4x3 destination, filled with white, as rows of
+		// characters: 'r' red, 'g' green, 'k' black and '.' white.
+		want []string
+	}{
+		{name: "inside", x: 1, y: 1, want: []string{"....", ".rr.", ".kg."}},
+		{name: "transparent color skipped", x: 1, y: 1, transparent: &black, want: []string{"....", ".rr.", "..g."}},
+		{name: "clipped left and top", x: -1, y: -1, want: []string{"g...", "....", "...."}},
+		{name: "clipped right and bottom", x: 3, y: 2, want: []string{"....", "....", "...r"}},
+		{name: "entirely outside", x: 4, y: -2, want: []string{"....", "....", "...."}},
+		{name: "far outside", x: -10, y: 10, want: []string{"....", "....", "...."}},
+	}
+
+	colors := map[byte]Color{'r': red, 'g': green, 'k': black, '.': white}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := NewFramebuffer(4, 3)
+			fb.Clear(white)
+			fb.Blit(sprite, tt.x, tt.y, tt.transparent)
+
+			want := NewFramebuffer(4, 3)
+			for y, row := range tt.want {
+				for x := range len(row) {
+					want.Pixels[y*want.Width+x] = colors[row[x]]
+				}
+			}
+			if !fb.Equal(want) {
+				t.Errorf("Blit(%d, %d) drew\n%s\nwant\n%s", tt.x, tt.y, fb.ASCII(), want.ASCII())
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// GIF frame delays are clamped to the frame durations animations accept. The
+// lower bound also matches how browsers play GIFs that ask for no delay.
+const (
+	minGIFFrameDelay = 100 * time.Millisecond
+	maxGIFFrameDelay = time.Minute
+)
+
+// maxImportPixels bounds the size an imported image may declare. Decoders
+// allocate from the dimensions in the header before reading any pixel data,
+// so a file of a few bytes claiming to be huge would otherwise exhaust
+// memory. It is generous for a 20x5 matrix.
+const maxImportPixels = 1 << 20
+
+// checkImportSize rejects images declaring more than maxImportPixels pixels.
+func checkImportSize(width, height int) error {
+	if int64(width)*int64(height) > maxImportPixels {
+		return fmt.Errorf("image is %dx%d pixels, at most %d pixels are allowed", width, height, maxImportPixels)
+	}
+	return nil
+}
+
+// FramesFromGIF decodes an animated GIF and fits every frame to the 20x5
+// matrix, ready for SaveAnimation.
+func FramesFromGIF(r io.Reader) ([][]Color, error) {
+	frames, _, err := decodeGIF(r)
+	return frames, err
+}
+
+// decodeGIF decodes an animated GIF into matrix frames and the delay of each
+// frame. Frames are composited onto the full canvas honouring each frame's
+// disposal method, so GIFs optimised to only store changed regions come out
+// complete. Frames never exceed the canvas declared in the header, so checking
+// its size bounds the memory decoding takes.
+func decodeGIF(r io.Reader) ([][]Color, []time.Duration, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read GIF: %w", err)
+	}
+	config, err := gif.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode GIF: %w", err)
+	}
+	if err := checkImportSize(config.Width, config.Height); err != nil {
+		return nil, nil, err
+	}
+
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode GIF: %w", err)
+	}
+	if len(g.Image) == 0 {
+		return nil, nil, errors.New("GIF has no frames")
+	}
+	if len(g.Image) > maxAnimationFrames {
+		return nil, nil, fmt.Errorf("GIF has %d frames, at most %d are allowed", len(g.Image), maxAnimationFrames)
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+	}
+	canvas := image.NewRGBA(bounds)
+
+	frames := make([][]Color, len(g.Image))
+	durations := make([]time.Duration, len(g.Image))
+	for i, paletted := range g.Image {
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, paletted.Bounds(), paletted, paletted.Bounds().Min, draw.Over)
+		frames[i] = sampleImage(canvas, matrixWidth, matrixHeight)
+
+		delay := 0
+		if i < len(g.Delay) {
+			delay = g.Delay[i]
+		}
+		durations[i] = min(max(time.Duration(delay)*10*time.Millisecond, minGIFFrameDelay), maxGIFFrameDelay)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, paletted.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, previous.Pix)
+		}
+	}
+	return frames, durations, nil
+}
+
+// sampleImage fits img to width x height pixels with nearest-neighbour
+// sampling. The image is cropped around its centre to the target aspect ratio
+// rather than stretched. Transparent pixels are composited over black.
+func sampleImage(img image.Image, width, height int) []Color {
+	crop := cropToAspect(img.Bounds(), width, height)
+
+	pixels := make([]Color, width*height)
+	if crop.Empty() {
+		return pixels
+	}
+	for y := range height {
+		srcY := crop.Min.Y + (2*y+1)*crop.Dy()/(2*height)
+		for x := range width {
+			srcX := crop.Min.X + (2*x+1)*crop.Dx()/(2*width)
+			// RGBA returns alpha-premultiplied values, which is exactly the
+			// colour composited over black.
+			r, g, b, _ := img.At(srcX, srcY).RGBA()
+			pixels[y*width+x] = Color{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8)}
+		}
+	}
+	return pixels
+}
+
+// cropToAspect returns the largest rectangle centred in bounds with the
+// aspect ratio width:height.
+func cropToAspect(bounds image.Rectangle, width, height int) image.Rectangle {
+	cropW, cropH := bounds.Dx(), bounds.Dy()
+	if cropW*height > cropH*width {
+		cropW = cropH * width / height
+	} else {
+		cropH = cropW * height / width
+	}
+	minX := bounds.Min.X + (bounds.Dx()-cropW)/2
+	minY := bounds.Min.Y + (bounds.Dy()-cropH)/2
+	return image.Rect(minX, minY, minX+cropW, minY+cropH)
+}
+
+// maxGIFScale bounds the upscaling factor of exported GIFs.
+const maxGIFScale = 50
+
+// AnimationToGIF renders the animation as a looping animated GIF, each matrix
+// pixel scaled up to a scale x scale block. Frames are shown for their own
+// durations when the animation has them, otherwise at fps frames per second,
+// or for the animation's frame duration when fps is 0.
+func AnimationToGIF(anim *SavedAnimation, scale int, fps int) ([]byte, error) {
+	if scale < 1 || scale > maxGIFScale {
+		return nil, fmt.Errorf("scale must be between 1 and %d", maxGIFScale)
+	}
+	if fps < 0 {
+		return nil, errors.New("fps must not be negative")
+	}
+	if err := validateFrames(anim.Frames, matrixWidth*matrixHeight); err != nil {
+		return nil, err
+	}
+
+	frameDuration := anim.FrameDuration
+	if fps > 0 {
+		frameDuration = time.Second / time.Duration(fps)
+	}
+	perFrame := len(anim.FrameDurations) == len(anim.Frames)
+
+	// GIF frames share one palette of at most 256 colours.
+	frames := QuantizeColors(anim.Frames, 256)
+	var palette color.Palette
+	paletteIndex := make(map[Color]uint8)
+	for _, frame := range frames {
+		for _, c := range frame {
+			if _, seen := paletteIndex[c]; !seen {
+				paletteIndex[c] = uint8(len(palette))
+				palette = append(palette, color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff})
+			}
+		}
+	}
+
+	g := &gif.GIF{
+		Image: make([]*image.Paletted, len(frames)),
+		Delay: make([]int, len(frames)),
+	}
+	bounds := image.Rect(0, 0, matrixWidth*scale, matrixHeight*scale)
+	for i, frame := range frames {
+		img := image.NewPaletted(bounds, palette)
+		for y := range bounds.Dy() {
+			for x := range bounds.Dx() {
+				img.SetColorIndex(x, y, paletteIndex[frame[(y/scale)*matrixWidth+x/scale]])
+			}
+		}
+		g.Image[i] = img
+
+		delay := frameDuration
+		if perFrame {
+			delay = anim.FrameDurations[i]
+		}
+		g.Delay[i] = int(delay / (10 * time.Millisecond))
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		return nil, fmt.Errorf("failed to encode GIF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
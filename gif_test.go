@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// encodeGIF returns a GIF with one frame of frameSize pixels on a canvas the
+// header declares as width x height.
+func encodeGIF(t *testing.T, width, height, frameSize int) []byte {
+	t.Helper()
+	palette := color.Palette{color.RGBA{R: 255, A: 255}}
+	g := &gif.GIF{
+		Image:  []*image.Paletted{image.NewPaletted(image.Rect(0, 0, frameSize, frameSize), palette)},
+		Delay:  []int{10},
+		Config: image.Config{ColorModel: palette, Width: width, Height: height},
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatalf("failed to encode GIF: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeGIFCanvasSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		frameSize     int
+		wantErr       bool
+	}{
+		{name: "small", width: 40, height: 10, frameSize: 10},
+		{name: "at the limit", width: 1024, height: 1024, frameSize: 1},
+		{name: "huge header with a tiny frame", width: 65535, height: 65535, frameSize: 1, wantErr: true},
+		{name: "wide header", width: 65535, height: 17, frameSize: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := encodeGIF(t, tt.width, tt.height, tt.frameSize)
+			frames, _, err := decodeGIF(bytes.NewReader(data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeGIF() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(frames) != 1 {
+				t.Errorf("decodeGIF() returned %d frames, want 1", len(frames))
+			}
+		})
+	}
+}
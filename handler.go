@@ -1,12 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"cubik/api"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"strconv"
+	"time"
 )
 
 type APIHandler struct {
@@ -15,35 +20,297 @@ type APIHandler struct {
 
 var _ api.Handler = (*APIHandler)(nil)
 
-func (h *APIHandler) GetDevices(_ context.Context) (api.GetDevicesRes, error) {
-	devices, err := DiscoverDevices()
+// recentDeviceWindow is how long a device that stopped answering discovery is
+// still listed, marked offline.
+const recentDeviceWindow = 24 * time.Hour
+
+func (h *APIHandler) GetDevices(ctx context.Context) (api.GetDevicesRes, error) {
+	devices, err := DiscoverDevices(ctx)
 	if err != nil {
-		slog.Error("Discovery error", "error", err)
+		slog.Debug("Discovery error", "error", err)
 		return &api.Error{Error: err.Error()}, nil
 	}
 
+	slog.Debug("Discovered devices", "count", len(devices))
+
+	now := time.Now()
+	online := make(map[string]bool, len(devices))
 	apiDevices := make([]api.Device, 0, len(devices))
 	for _, device := range devices {
+		online[device.ID] = true
+		apiDevices = append(apiDevices, api.Device{
+			ID:         device.ID,
+			Name:       device.Name,
+			Location:   device.Location,
+			IDConflict: device.IDConflict,
+			Online:     api.NewOptBool(true),
+			LastSeen:   api.NewOptDateTime(now.UTC()),
+		})
+
+		// Devices sharing an ID would overwrite each other's record.
+		if device.ID == "" || device.IDConflict {
+			continue
+		}
+		if upsertErr := UpsertDevice(ctx, h.db, device); upsertErr != nil {
+			slog.Warn("Failed to remember device", "device", device.Location, "error", upsertErr)
+		}
+	}
+
+	saved, err := ListDevices(ctx, h.db, now.Add(-recentDeviceWindow))
+	if err != nil {
+		slog.Warn("Failed to list remembered devices", "error", err)
+	}
+	for _, device := range saved {
+		if online[device.ID] {
+			continue
+		}
 		apiDevices = append(apiDevices, api.Device{
 			ID:       device.ID,
 			Name:     device.Name,
 			Location: device.Location,
+			Online:   api.NewOptBool(false),
+			LastSeen: api.NewOptDateTime(device.LastSeen),
 		})
 	}
 
 	return &api.GetDevicesOK{Devices: apiDevices}, nil
 }
 
+func (h *APIHandler) GetDeviceStatus(
+	ctx context.Context,
+	params api.GetDeviceStatusParams,
+) (api.GetDeviceStatusRes, error) {
+	// Within the reachability TTL the device is not queried again, whether
+	// it answered last time or not.
+	reachable, props, known := reachabilityCache.Fresh(params.DeviceLocation)
+	if known && !reachable {
+		return &api.Error{Error: "device is unreachable"}, nil
+	}
+
+	device := &DeviceInfo{Location: params.DeviceLocation}
+
+	var err error
+	if props == nil {
+		props, err = GetPropContext(ctx, device, "power", "bright", "color_mode")
+		if err != nil {
+			var netErr net.Error
+			reachabilityCache.Record(params.DeviceLocation, !errors.As(err, &netErr))
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			return &api.Error{Error: fmt.Sprintf("failed to query device status: %v", err)}, nil
+		}
+		reachabilityCache.RecordStatus(params.DeviceLocation, props)
+	}
+
+	device.Model, err = DeviceModel(ctx, h.db, params.DeviceLocation)
+	if err != nil {
+		slog.Warn("Failed to look up device model", "device", params.DeviceLocation, "error", err)
+	}
+	caps, err := GetColorCapabilitiesContext(ctx, device)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return &api.Error{Error: err.Error()}, nil
+	}
+
+	brightness, _ := strconv.Atoi(props["bright"])
+	colorMode, _ := strconv.Atoi(props["color_mode"])
+	animation, running := DeviceAnimation(params.DeviceLocation)
+
+	status := &api.DeviceStatusResponse{
+		Power:      props["power"] == "on",
+		Brightness: brightness,
+		ColorMode:  colorMode,
+		Capabilities: api.ColorCapabilities{
+			Rgb:          caps.RGB,
+			Ct:           caps.CT,
+			Hsv:          caps.HSV,
+			MinCt:        caps.MinCT,
+			MaxCt:        caps.MaxCT,
+			CtRangeKnown: caps.CTRangeKnown,
+		},
+		AnimationRunning:        running,
+		AnimationPausedForPower: running && animation.PausedForPower.Load(),
+	}
+	if running {
+		status.AnimationPaused = api.NewOptBool(animation.Paused())
+		status.AnimationFps = api.NewOptFloat64(animation.EffectiveFPS())
+		status.AnimationAdaptiveFps = api.NewOptFloat64(animation.AdaptiveFPS())
+	}
+	return status, nil
+}
+
+func (h *APIHandler) PingDevice(ctx context.Context, params api.PingDeviceParams) (*api.PingDeviceResponse, error) {
+	reachable, checkedAt := reachabilityCache.Check(ctx, params.DeviceLocation)
+	return &api.PingDeviceResponse{Reachable: reachable, CheckedAt: checkedAt.UTC()}, nil
+}
+
+func (h *APIHandler) ApplyDeviceState(
+	ctx context.Context,
+	req *api.ApplyDeviceStateRequest,
+) (api.ApplyDeviceStateRes, error) {
+	var commands []Command
+	if on, ok := req.Power.Get(); ok && on {
+		commands = append(commands, Command{Method: "set_power", Params: []any{"on", "sudden", 0}})
+	}
+	if brightness, ok := req.Brightness.Get(); ok {
+		commands = append(commands, Command{Method: "set_bright", Params: []any{brightness, "sudden", 0}})
+	}
+	if color, ok := req.Color.Get(); ok {
+		rgb := int(color.R)<<16 | int(color.G)<<8 | int(color.B)
+		commands = append(commands, Command{Method: "set_rgb", Params: []any{rgb, "sudden", 0}})
+	}
+	if on, ok := req.Power.Get(); ok && !on {
+		commands = append(commands, Command{Method: "set_power", Params: []any{"off", "sudden", 0}})
+	}
+
+	if len(commands) == 0 {
+		return &api.ApplyDeviceStateBadRequest{
+			Error: "at least one of power, brightness or color is required",
+		}, nil
+	}
+
+	device := &DeviceInfo{Location: req.DeviceLocation}
+	_, err := SendBatchContext(ctx, device, commands)
+	reachabilityCache.ForgetStatus(req.DeviceLocation)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return &api.ApplyDeviceStateInternalServerError{
+			Error: fmt.Sprintf("failed to apply device state: %v", err),
+		}, nil
+	}
+
+	return &api.ApplyDeviceStateResponse{
+		Message:      "Device state applied successfully",
+		CommandCount: len(commands),
+	}, nil
+}
+
+func (h *APIHandler) SetDeviceName(
+	ctx context.Context,
+	req *api.SetDeviceNameRequest,
+) (api.SetDeviceNameRes, error) {
+	device := &DeviceInfo{Location: req.DeviceLocation}
+	name, err := SetDeviceNameContext(ctx, device, req.Name)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return &api.SetDeviceNameInternalServerError{
+			Error: fmt.Sprintf("failed to rename device: %v", err),
+		}, nil
+	}
+
+	return &api.SetDeviceNameResponse{
+		Message: "Device renamed successfully",
+		Name:    name,
+	}, nil
+}
+
+func (h *APIHandler) SetDevicePower(
+	ctx context.Context,
+	req *api.SetDevicePowerRequest,
+) (api.SetDevicePowerRes, error) {
+	device := &DeviceInfo{Location: req.DeviceLocation}
+	err := SetPowerContext(ctx, device, req.Power, "sudden", 0)
+	reachabilityCache.ForgetStatus(req.DeviceLocation)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return &api.SetDevicePowerInternalServerError{
+			Error: fmt.Sprintf("failed to set device power: %v", err),
+		}, nil
+	}
+
+	return &api.SetDevicePowerResponse{
+		Message: "Device power set successfully",
+		Power:   req.Power,
+	}, nil
+}
+
+func (h *APIHandler) SetDeviceBrightness(
+	ctx context.Context,
+	req *api.SetDeviceBrightnessRequest,
+) (api.SetDeviceBrightnessRes, error) {
+	device := &DeviceInfo{Location: req.DeviceLocation}
+	err := SetBrightnessContext(ctx, device, req.Brightness)
+	reachabilityCache.ForgetStatus(req.DeviceLocation)
+	if errors.Is(err, ErrBrightnessOutOfRange) {
+		return &api.SetDeviceBrightnessBadRequest{Error: err.Error()}, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return &api.SetDeviceBrightnessInternalServerError{
+			Error: fmt.Sprintf("failed to set device brightness: %v", err),
+		}, nil
+	}
+
+	return &api.SetDeviceBrightnessResponse{
+		Message:    "Device brightness set successfully",
+		Brightness: req.Brightness,
+	}, nil
+}
+
+func (h *APIHandler) GetWhiteBalance(
+	_ context.Context,
+	params api.GetWhiteBalanceParams,
+) (api.GetWhiteBalanceRes, error) {
+	wb := DeviceWhiteBalance(params.DeviceLocation)
+	return &api.WhiteBalance{R: wb.R, G: wb.G, B: wb.B}, nil
+}
+
+func (h *APIHandler) SetWhiteBalance(
+	ctx context.Context,
+	req *api.SetWhiteBalanceRequest,
+) (api.SetWhiteBalanceRes, error) {
+	wb := WhiteBalance{R: req.WhiteBalance.R, G: req.WhiteBalance.G, B: req.WhiteBalance.B}
+	if err := SetDeviceWhiteBalance(ctx, h.db, req.DeviceLocation, wb); err != nil {
+		return &api.SetWhiteBalanceInternalServerError{Error: err.Error()}, nil
+	}
+	return &req.WhiteBalance, nil
+}
+
 func (h *APIHandler) StartAnimation(
 	_ context.Context,
 	req *api.StartAnimationRequest,
 ) (api.StartAnimationRes, error) {
-	internalFrames := make([][]Color, len(req.Frames))
-	for i, apiFrame := range req.Frames {
-		internalFrames[i] = ConvertAPIFrameToColors(apiFrame)
+	frameDuration, err := frameDurationFromTiming(req.Fps, req.FrameDurationMs)
+	if err != nil {
+		return &api.StartAnimationBadRequest{Error: err.Error()}, nil
+	}
+	frameDurations, err := frameDurationsFromMs(req.FrameDurationsMs, len(req.Frames))
+	if err != nil {
+		return &api.StartAnimationBadRequest{Error: err.Error()}, nil
+	}
+	internalFrames, err := convertAPIFrames(req.Frames)
+	if err != nil {
+		return &api.StartAnimationBadRequest{Error: err.Error()}, nil
 	}
 
-	StartDeviceAnimation(req.DeviceLocation, internalFrames)
+	state := &AnimationState{
+		DeviceLocation:  req.DeviceLocation,
+		Frames:          internalFrames,
+		FrameDuration:   frameDuration,
+		FrameDurations:  frameDurations,
+		Mode:            playbackModeFromAPI(req.PlaybackMode.Or(api.StartAnimationRequestPlaybackModeForward)),
+		LoopCount:       req.LoopCount.Or(0),
+		TransitionSteps: req.TransitionSteps.Or(0),
+		ClearOnStop:     req.ClearOnStop.Or(false),
+	}
+	if req.Preview.Or(false) {
+		if err := StartDevicePreview(state); err != nil {
+			return &api.StartAnimationConflict{Error: err.Error()}, nil
+		}
+	} else {
+		StartDeviceAnimation(state)
+	}
 
 	return &api.StartAnimationResponse{
 		Message:    "Animation started successfully",
@@ -56,13 +323,139 @@ func (h *APIHandler) StopAnimation(_ context.Context, req *api.StopAnimationRequ
 	return &api.StopAnimationResponse{Message: "Animation stopped successfully"}, nil
 }
 
+func (h *APIHandler) ListRunningAnimations(_ context.Context) (*api.RunningAnimationsResponse, error) {
+	states := runningAnimationStates()
+	animations := make([]api.RunningAnimation, len(states))
+	for i, state := range states {
+		status := state.status()
+		animations[i] = api.RunningAnimation{
+			DeviceLocation: state.DeviceLocation,
+			FrameIndex:     status.FrameIndex,
+			FrameCount:     status.FrameCount,
+			Paused:         status.Paused,
+			PausedForPower: status.PausedForPower,
+			Preview:        state.Preview,
+		}
+	}
+	return &api.RunningAnimationsResponse{Animations: animations}, nil
+}
+
+func (h *APIHandler) PauseAnimation(
+	_ context.Context,
+	req *api.PauseAnimationRequest,
+) (api.PauseAnimationRes, error) {
+	err := PauseDeviceAnimation(req.DeviceLocation)
+	if errors.Is(err, ErrNoAnimation) {
+		return &api.PauseAnimationNotFound{Error: err.Error()}, nil
+	}
+	if err != nil {
+		return &api.PauseAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to pause animation: %v", err),
+		}, nil
+	}
+	return &api.PauseAnimationResponse{Message: "Animation paused successfully"}, nil
+}
+
+func (h *APIHandler) ResumeAnimation(
+	_ context.Context,
+	req *api.ResumeAnimationRequest,
+) (api.ResumeAnimationRes, error) {
+	err := ResumeDeviceAnimation(req.DeviceLocation)
+	if errors.Is(err, ErrNoAnimation) {
+		return &api.ResumeAnimationNotFound{Error: err.Error()}, nil
+	}
+	if err != nil {
+		return &api.ResumeAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to resume animation: %v", err),
+		}, nil
+	}
+	return &api.ResumeAnimationResponse{Message: "Animation resumed successfully"}, nil
+}
+
+func (h *APIHandler) StartPlaylist(
+	ctx context.Context,
+	req *api.StartPlaylistRequest,
+) (api.StartPlaylistRes, error) {
+	var animations []*SavedAnimation
+	if tag, byTag := req.Tag.Get(); byTag {
+		if len(req.AnimationIds) > 0 {
+			return &api.StartPlaylistBadRequest{Error: "animation_ids and tag are mutually exclusive"}, nil
+		}
+		deviceID, ok := req.DeviceID.Get()
+		if !ok {
+			return &api.StartPlaylistBadRequest{Error: "device_id is required with tag"}, nil
+		}
+		tagged, err := FilterAnimations(ctx, h.db, deviceID, AnimationFilter{
+			Tag:       tag,
+			SortBy:    "created_at",
+			Ascending: true,
+		})
+		if err != nil {
+			return &api.StartPlaylistInternalServerError{
+				Error: fmt.Sprintf("failed to list animations tagged %q: %v", tag, err),
+			}, nil
+		}
+		if len(tagged) == 0 {
+			return &api.StartPlaylistNotFound{Error: fmt.Sprintf("no animations tagged %q", tag)}, nil
+		}
+		animations = tagged
+	} else {
+		if len(req.AnimationIds) == 0 {
+			return &api.StartPlaylistBadRequest{Error: "either animation_ids or tag is required"}, nil
+		}
+		for _, id := range req.AnimationIds {
+			animation, err := GetAnimation(ctx, h.db, id)
+			if errors.Is(err, ErrNotFound) {
+				return &api.StartPlaylistNotFound{Error: fmt.Sprintf("animation not found: %s", id)}, nil
+			}
+			if err != nil {
+				return &api.StartPlaylistInternalServerError{
+					Error: fmt.Sprintf("failed to load animation %s: %v", id, err),
+				}, nil
+			}
+			animations = append(animations, animation)
+		}
+	}
+
+	playlist := make([]PlaylistItem, len(animations))
+	for i, animation := range animations {
+		playlist[i] = PlaylistItem{
+			Frames:         animation.Frames,
+			FrameDuration:  animation.FrameDuration,
+			FrameDurations: animation.FrameDurations,
+		}
+	}
+
+	itemDuration := time.Duration(req.ItemDurationMs) * time.Millisecond
+	StartDevicePlaylist(req.DeviceLocation, playlist, itemDuration)
+
+	return &api.StartPlaylistResponse{
+		Message:   "Playlist started successfully",
+		ItemCount: len(playlist),
+	}, nil
+}
+
 func (h *APIHandler) SaveAnimation(ctx context.Context, req *api.SaveAnimationRequest) (api.SaveAnimationRes, error) {
-	frames := make([][]Color, len(req.Frames))
-	for i, apiFrame := range req.Frames {
-		frames[i] = ConvertAPIFrameToColors(apiFrame)
+	frames, err := convertAPIFrames(req.Frames)
+	if err != nil {
+		return &api.SaveAnimationBadRequest{Error: err.Error()}, nil
 	}
 
-	animation, err := SaveAnimation(ctx, h.db, req.DeviceID, req.Name, frames)
+	frameDurations, err := frameDurationsFromMs(req.FrameDurationsMs, len(req.Frames))
+	if err != nil {
+		return &api.SaveAnimationBadRequest{Error: err.Error()}, nil
+	}
+
+	animation, err := SaveAnimation(
+		ctx,
+		h.db,
+		req.DeviceID,
+		req.Name,
+		frames,
+		frameDurationFromMs(req.FrameDurationMs),
+		frameDurations,
+		req.Tags,
+	)
 	if err != nil {
 		return &api.SaveAnimationInternalServerError{
 			Error: fmt.Sprintf("failed to save animation: %v", err),
@@ -76,11 +469,94 @@ func (h *APIHandler) SaveAnimation(ctx context.Context, req *api.SaveAnimationRe
 	}, nil
 }
 
+func (h *APIHandler) ImportGifAnimation(
+	ctx context.Context,
+	req api.ImportGifAnimationReq,
+	params api.ImportGifAnimationParams,
+) (api.ImportGifAnimationRes, error) {
+	frames, frameDurations, err := decodeGIF(req)
+	if err != nil {
+		return &api.ImportGifAnimationBadRequest{Error: err.Error()}, nil
+	}
+
+	animation, err := SaveAnimation(
+		ctx,
+		h.db,
+		params.DeviceID,
+		params.Name,
+		frames,
+		defaultFrameDuration,
+		frameDurations,
+		nil,
+	)
+	if err != nil {
+		return &api.ImportGifAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to save animation: %v", err),
+		}, nil
+	}
+
+	return &api.SaveAnimationResponse{
+		ID:        animation.ID,
+		Message:   "Animation imported successfully",
+		Animation: convertToAPIAnimation(animation),
+	}, nil
+}
+
+func (h *APIHandler) ImportImageAnimation(
+	ctx context.Context,
+	req api.ImportImageAnimationReq,
+	params api.ImportImageAnimationParams,
+) (api.ImportImageAnimationRes, error) {
+	var body io.Reader
+	switch upload := req.(type) {
+	case *api.ImportImageAnimationReqImagePNG:
+		body = upload
+	case *api.ImportImageAnimationReqImageJpeg:
+		body = upload
+	default:
+		return &api.ImportImageAnimationBadRequest{Error: "unsupported image type"}, nil
+	}
+
+	frame, err := decodeImageFrame(body)
+	if err != nil {
+		return &api.ImportImageAnimationBadRequest{Error: err.Error()}, nil
+	}
+
+	animation, err := SaveAnimation(
+		ctx,
+		h.db,
+		params.DeviceID,
+		params.Name,
+		[][]Color{frame},
+		defaultFrameDuration,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return &api.ImportImageAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to save animation: %v", err),
+		}, nil
+	}
+
+	return &api.SaveAnimationResponse{
+		ID:        animation.ID,
+		Message:   "Image imported successfully",
+		Animation: convertToAPIAnimation(animation),
+	}, nil
+}
+
 func (h *APIHandler) ListAnimations(
 	ctx context.Context,
 	params api.ListAnimationsParams,
 ) (api.ListAnimationsRes, error) {
-	animations, err := ListAnimationsByDevice(ctx, h.db, params.DeviceID)
+	animations, err := FilterAnimations(ctx, h.db, params.DeviceID, AnimationFilter{
+		Query:         params.Q.Or(""),
+		Tag:           params.Tag.Or(""),
+		CreatedAfter:  params.CreatedAfter.Or(time.Time{}),
+		CreatedBefore: params.CreatedBefore.Or(time.Time{}),
+		SortBy:        string(params.Sort.Or(api.ListAnimationsSortUpdatedAt)),
+		Ascending:     params.Order.Or(api.ListAnimationsOrderDesc) == api.ListAnimationsOrderAsc,
+	})
 	if err != nil {
 		return &api.Error{Error: fmt.Sprintf("failed to list animations: %v", err)}, nil
 	}
@@ -93,6 +569,34 @@ func (h *APIHandler) ListAnimations(
 	return &api.ListAnimationsResponse{Animations: apiAnimations}, nil
 }
 
+func (h *APIHandler) DeleteDeviceAnimations(
+	ctx context.Context,
+	params api.DeleteDeviceAnimationsParams,
+) (api.DeleteDeviceAnimationsRes, error) {
+	deleted, err := DeleteAnimationsByDevice(ctx, h.db, params.DeviceID)
+	if err != nil {
+		return &api.Error{Error: fmt.Sprintf("failed to delete animations: %v", err)}, nil
+	}
+	return &api.DeleteAnimationsResponse{
+		Message:      "Animations deleted successfully",
+		DeletedCount: deleted,
+	}, nil
+}
+
+func (h *APIHandler) DeleteAnimations(
+	ctx context.Context,
+	req *api.DeleteAnimationsRequest,
+) (api.DeleteAnimationsRes, error) {
+	deleted, err := DeleteAnimationsByIDs(ctx, h.db, req.Ids)
+	if err != nil {
+		return &api.Error{Error: fmt.Sprintf("failed to delete animations: %v", err)}, nil
+	}
+	return &api.DeleteAnimationsResponse{
+		Message:      "Animations deleted successfully",
+		DeletedCount: deleted,
+	}, nil
+}
+
 func (h *APIHandler) GetAnimation(ctx context.Context, params api.GetAnimationParams) (api.GetAnimationRes, error) {
 	animation, err := GetAnimation(ctx, h.db, params.ID)
 	if errors.Is(err, ErrNotFound) {
@@ -107,20 +611,112 @@ func (h *APIHandler) GetAnimation(ctx context.Context, params api.GetAnimationPa
 	return &api.GetAnimationResponse{Animation: convertToAPIAnimation(animation)}, nil
 }
 
+func (h *APIHandler) DuplicateAnimation(
+	ctx context.Context,
+	req api.OptDuplicateAnimationRequest,
+	params api.DuplicateAnimationParams,
+) (api.DuplicateAnimationRes, error) {
+	animation, err := DuplicateAnimation(ctx, h.db, params.ID, req.Or(api.DuplicateAnimationRequest{}).Name.Or(""))
+	if errors.Is(err, ErrNotFound) {
+		return &api.DuplicateAnimationNotFound{Error: "animation not found"}, nil
+	}
+	if err != nil {
+		return &api.DuplicateAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to duplicate animation: %v", err),
+		}, nil
+	}
+
+	return &api.SaveAnimationResponse{
+		ID:        animation.ID,
+		Message:   "Animation duplicated successfully",
+		Animation: convertToAPIAnimation(animation),
+	}, nil
+}
+
+func (h *APIHandler) ExportAnimationGif(
+	ctx context.Context,
+	params api.ExportAnimationGifParams,
+) (api.ExportAnimationGifRes, error) {
+	animation, err := GetAnimation(ctx, h.db, params.ID)
+	if errors.Is(err, ErrNotFound) {
+		return &api.ExportAnimationGifNotFound{Error: "animation not found"}, nil
+	}
+	if err != nil {
+		return &api.ExportAnimationGifInternalServerError{
+			Error: fmt.Sprintf("failed to get animation: %v", err),
+		}, nil
+	}
+
+	data, err := AnimationToGIF(animation, params.Scale.Or(10), params.Fps.Or(0))
+	if err != nil {
+		return &api.ExportAnimationGifInternalServerError{
+			Error: fmt.Sprintf("failed to export animation: %v", err),
+		}, nil
+	}
+	return &api.ExportAnimationGifOK{Data: bytes.NewReader(data)}, nil
+}
+
+func (h *APIHandler) GetAnimationThumbnail(
+	ctx context.Context,
+	params api.GetAnimationThumbnailParams,
+) (api.GetAnimationThumbnailRes, error) {
+	animation, err := GetAnimation(ctx, h.db, params.ID)
+	if errors.Is(err, ErrNotFound) {
+		return &api.GetAnimationThumbnailNotFound{Error: "animation not found"}, nil
+	}
+	if err != nil {
+		return &api.GetAnimationThumbnailInternalServerError{
+			Error: fmt.Sprintf("failed to get animation: %v", err),
+		}, nil
+	}
+
+	fb := NewMatrixFramebuffer()
+	if len(animation.Frames) > 0 {
+		copy(fb.Pixels, animation.Frames[0])
+	}
+	data, err := fb.PNG(params.Scale.Or(10))
+	if err != nil {
+		return &api.GetAnimationThumbnailInternalServerError{
+			Error: fmt.Sprintf("failed to render thumbnail: %v", err),
+		}, nil
+	}
+	return &api.GetAnimationThumbnailOK{Data: bytes.NewReader(data)}, nil
+}
+
 func (h *APIHandler) UpdateAnimation(
 	ctx context.Context,
 	req *api.UpdateAnimationRequest,
 	params api.UpdateAnimationParams,
 ) (api.UpdateAnimationRes, error) {
-	frames := make([][]Color, len(req.Frames))
-	for i, apiFrame := range req.Frames {
-		frames[i] = ConvertAPIFrameToColors(apiFrame)
+	frames, err := convertAPIFrames(req.Frames)
+	if err != nil {
+		return &api.UpdateAnimationBadRequest{Error: err.Error()}, nil
+	}
+	frameDurations, err := frameDurationsFromMs(req.FrameDurationsMs, len(frames))
+	if err != nil {
+		return &api.UpdateAnimationBadRequest{Error: err.Error()}, nil
+	}
+	if req.FrameDurationsMs != nil && frameDurations == nil {
+		// An explicit empty list drops the per-frame durations.
+		frameDurations = []time.Duration{}
+	}
+	var frameDuration time.Duration
+	if req.FrameDurationMs.IsSet() {
+		frameDuration = frameDurationFromMs(req.FrameDurationMs)
 	}
 
-	animation, err := UpdateAnimation(ctx, h.db, params.ID, req.Name, frames)
+	animation, err := UpdateAnimation(
+		ctx, h.db, params.ID, req.Name, frames, frameDuration, frameDurations, req.Tags,
+	)
 	if errors.Is(err, ErrNotFound) {
 		return &api.UpdateAnimationNotFound{Error: "animation not found"}, nil
 	}
+	if errors.Is(err, ErrFrameDurationsMismatch) {
+		return &api.UpdateAnimationBadRequest{
+			Error: "frame count changed; send frame_durations_ms with one entry per frame, " +
+				"or an empty list to drop them",
+		}, nil
+	}
 	if err != nil {
 		return &api.UpdateAnimationInternalServerError{
 			Error: fmt.Sprintf("failed to update animation: %v", err),
@@ -133,6 +729,52 @@ func (h *APIHandler) UpdateAnimation(
 	}, nil
 }
 
+func (h *APIHandler) UndoAnimation(
+	ctx context.Context,
+	params api.UndoAnimationParams,
+) (api.UndoAnimationRes, error) {
+	animation, err := UndoAnimation(ctx, h.db, params.ID)
+	if errors.Is(err, ErrNotFound) {
+		return &api.UndoAnimationNotFound{Error: "animation not found"}, nil
+	}
+	if errors.Is(err, ErrNoRevision) {
+		return &api.UndoAnimationConflict{Error: "nothing to undo"}, nil
+	}
+	if err != nil {
+		return &api.UndoAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to undo animation: %v", err),
+		}, nil
+	}
+
+	return &api.UpdateAnimationResponse{
+		Message:   "Animation update undone",
+		Animation: convertToAPIAnimation(animation),
+	}, nil
+}
+
+func (h *APIHandler) RedoAnimation(
+	ctx context.Context,
+	params api.RedoAnimationParams,
+) (api.RedoAnimationRes, error) {
+	animation, err := RedoAnimation(ctx, h.db, params.ID)
+	if errors.Is(err, ErrNotFound) {
+		return &api.RedoAnimationNotFound{Error: "animation not found"}, nil
+	}
+	if errors.Is(err, ErrNoRevision) {
+		return &api.RedoAnimationConflict{Error: "nothing to redo"}, nil
+	}
+	if err != nil {
+		return &api.RedoAnimationInternalServerError{
+			Error: fmt.Sprintf("failed to redo animation: %v", err),
+		}, nil
+	}
+
+	return &api.UpdateAnimationResponse{
+		Message:   "Animation update redone",
+		Animation: convertToAPIAnimation(animation),
+	}, nil
+}
+
 func (h *APIHandler) DeleteAnimation(
 	ctx context.Context,
 	params api.DeleteAnimationParams,
@@ -150,6 +792,80 @@ func (h *APIHandler) DeleteAnimation(
 	return &api.DeleteAnimationResponse{Message: "Animation deleted successfully"}, nil
 }
 
+// frameDurationFromMs converts an optional frame_duration_ms request field,
+// falling back to defaultFrameDuration when it is omitted.
+func frameDurationFromMs(ms api.OptInt) time.Duration {
+	if value, ok := ms.Get(); ok {
+		return time.Duration(value) * time.Millisecond
+	}
+	return defaultFrameDuration
+}
+
+// Frame rate bounds accepted by the fps request field. Requests are further
+// limited to animationMaxFPS, which playback never exceeds.
+const (
+	minRequestFPS = 1
+	maxRequestFPS = 30
+)
+
+// frameDurationFromTiming converts the mutually exclusive fps and
+// frame_duration_ms request fields to a frame duration. Timing faster than
+// animationMaxFPS is rejected rather than silently slowed down.
+func frameDurationFromTiming(fps api.OptInt, ms api.OptInt) (time.Duration, error) {
+	value, ok := fps.Get()
+	if !ok {
+		frameDuration := frameDurationFromMs(ms)
+		if shortest := fpsToInterval(animationMaxFPS); frameDuration < shortest {
+			return 0, fmt.Errorf("frame_duration_ms must be at least %d", shortest.Milliseconds())
+		}
+		return frameDuration, nil
+	}
+	if ms.IsSet() {
+		return 0, errors.New("fps and frame_duration_ms are mutually exclusive")
+	}
+	if highest := min(maxRequestFPS, animationMaxFPS); value < minRequestFPS || float64(value) > highest {
+		return 0, fmt.Errorf("fps must be between %d and %g", minRequestFPS, highest)
+	}
+	return time.Second / time.Duration(value), nil
+}
+
+// convertAPIFrames converts request frames, rejecting any frame that does not
+// cover the matrix exactly, so partial frames are never stored or displayed.
+func convertAPIFrames(apiFrames []api.AnimationFrame) ([][]Color, error) {
+	frames := make([][]Color, len(apiFrames))
+	for i, apiFrame := range apiFrames {
+		frames[i] = ConvertAPIFrameToColors(apiFrame)
+	}
+	if err := validateFrames(frames, matrixWidth*matrixHeight); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// frameDurationsFromMs converts the optional frame_durations_ms request field,
+// which must have one entry per frame when present.
+func frameDurationsFromMs(ms []int, frameCount int) ([]time.Duration, error) {
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	if len(ms) != frameCount {
+		return nil, fmt.Errorf("frame_durations_ms has %d entries, expected one per frame (%d)", len(ms), frameCount)
+	}
+
+	durations := make([]time.Duration, len(ms))
+	for i, value := range ms {
+		durations[i] = time.Duration(value) * time.Millisecond
+	}
+	return durations, nil
+}
+
+func playbackModeFromAPI(mode api.StartAnimationRequestPlaybackMode) PlaybackMode {
+	if mode == api.StartAnimationRequestPlaybackModePingPong {
+		return PlaybackPingPong
+	}
+	return PlaybackForward
+}
+
 func convertToAPIAnimation(anim *SavedAnimation) api.SavedAnimation {
 	apiFrames := make([]api.AnimationFrame, len(anim.Frames))
 	for i, frame := range anim.Frames {
@@ -163,12 +879,20 @@ func convertToAPIAnimation(anim *SavedAnimation) api.SavedAnimation {
 		}
 	}
 
+	var frameDurationsMs []int
+	for _, duration := range anim.FrameDurations {
+		frameDurationsMs = append(frameDurationsMs, int(duration.Milliseconds()))
+	}
+
 	return api.SavedAnimation{
-		ID:        anim.ID,
-		DeviceID:  anim.DeviceID,
-		Name:      anim.Name,
-		Frames:    apiFrames,
-		CreatedAt: anim.CreatedAt,
-		UpdatedAt: anim.UpdatedAt,
+		ID:               anim.ID,
+		DeviceID:         anim.DeviceID,
+		Name:             anim.Name,
+		Frames:           apiFrames,
+		FrameDurationMs:  int(anim.FrameDuration.Milliseconds()),
+		FrameDurationsMs: frameDurationsMs,
+		Tags:             anim.Tags,
+		CreatedAt:        anim.CreatedAt,
+		UpdatedAt:        anim.UpdatedAt,
 	}
 }
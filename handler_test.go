@@ -0,0 +1,72 @@
+package main
+
+import (
+	"cubik/api"
+	"testing"
+	"time"
+)
+
+func TestFrameDurationFromTiming(t *testing.T) {
+	tests := []struct {
+		name    string
+		fps     api.OptInt
+		ms      api.OptInt
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "default", want: defaultFrameDuration},
+		{name: "milliseconds", ms: api.NewOptInt(250), want: 250 * time.Millisecond},
+		{name: "seconds", ms: api.NewOptInt(2500), want: 2500 * time.Millisecond},
+		{name: "fps", fps: api.NewOptInt(4), want: 250 * time.Millisecond},
+		{name: "both", fps: api.NewOptInt(4), ms: api.NewOptInt(250), wantErr: true},
+		{name: "faster than the playback limit", ms: api.NewOptInt(50), wantErr: true},
+		{name: "fps above the playback limit", fps: api.NewOptInt(int(animationMaxFPS) + 1), wantErr: true},
+		{name: "zero fps", fps: api.NewOptInt(0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := frameDurationFromTiming(tt.fps, tt.ms)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("frameDurationFromTiming() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("frameDurationFromTiming() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameDurationPlaybackRate(t *testing.T) {
+	tests := []struct {
+		ms      int
+		wantFPS float64
+	}{
+		{ms: 250, wantFPS: 4},
+		{ms: 1000, wantFPS: 1},
+		{ms: 2000, wantFPS: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run((time.Duration(tt.ms) * time.Millisecond).String(), func(t *testing.T) {
+			frameDuration, err := frameDurationFromTiming(api.OptInt{}, api.NewOptInt(tt.ms))
+			if err != nil {
+				t.Fatalf("frameDurationFromTiming() error = %v", err)
+			}
+
+			// Frames are delivered exactly on schedule by a fake clock.
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			scheduler := newFrameScheduler(start, frameDuration)
+			meter := newFPSMeter(start)
+			now := start
+			for range 20 {
+				now = scheduler.Next(now)
+				meter.Frame(now)
+			}
+
+			if got := meter.FPS(); got != tt.wantFPS {
+				t.Errorf("played at %g fps, want %g", got, tt.wantFPS)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+)
+
+// FrameFromImage fits img to the 20x5 matrix as a single frame. The image is
+// cropped around its centre to the matrix aspect ratio and every pixel is the
+// average of the area it covers, so detail is not lost to aliasing. Images
+// with alpha are composited over black.
+func FrameFromImage(img image.Image) []Color {
+	crop := cropToAspect(img.Bounds(), matrixWidth, matrixHeight)
+	if crop.Dx() < matrixWidth || crop.Dy() < matrixHeight {
+		// Too small to average; fall back to sampling.
+		return sampleImage(img, matrixWidth, matrixHeight)
+	}
+
+	pixels := make([]Color, matrixWidth*matrixHeight)
+	for y := range matrixHeight {
+		y0 := crop.Min.Y + y*crop.Dy()/matrixHeight
+		y1 := crop.Min.Y + (y+1)*crop.Dy()/matrixHeight
+		for x := range matrixWidth {
+			x0 := crop.Min.X + x*crop.Dx()/matrixWidth
+			x1 := crop.Min.X + (x+1)*crop.Dx()/matrixWidth
+
+			var sumR, sumG, sumB, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// RGBA returns alpha-premultiplied values, which is
+					// exactly the colour composited over black.
+					r, g, b, _ := img.At(sx, sy).RGBA()
+					sumR += uint64(r)
+					sumG += uint64(g)
+					sumB += uint64(b)
+					count++
+				}
+			}
+			pixels[y*matrixWidth+x] = Color{
+				R: uint8(sumR / count >> 8),
+				G: uint8(sumG / count >> 8),
+				B: uint8(sumB / count >> 8),
+			}
+		}
+	}
+	return pixels
+}
+
+// decodeImageFrame decodes a PNG or JPEG image into a single matrix frame.
+// The dimensions in the header are checked before the image is decoded.
+func decodeImageFrame(r io.Reader) ([]Color, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if err := checkImportSize(config.Width, config.Height); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return FrameFromImage(img), nil
+}
+
+// PNG renders the pixels as a PNG image with every pixel scaled up to a
+// scale x scale block. Encoding settings are not applied, so the image shows
+// the colors as authored.
+func (fb *Framebuffer) PNG(scale int) ([]byte, error) {
+	if scale < 1 {
+		return nil, fmt.Errorf("invalid scale %d", scale)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, fb.Width*scale, fb.Height*scale))
+	for y := range fb.Height * scale {
+		for x := range fb.Width * scale {
+			c := fb.Pixels[(y/scale)*fb.Width+x/scale]
+			img.SetRGBA(x, y, color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
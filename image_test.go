@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"testing"
+)
+
+// encodePNG returns a 1x1 PNG whose header declares width x height.
+func encodePNG(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	data := buf.Bytes()
+	// IHDR follows the 8-byte signature: length, type, then width and
+	// height, with the chunk's CRC after its 13 bytes of data.
+	binary.BigEndian.PutUint32(data[16:], width)
+	binary.BigEndian.PutUint32(data[20:], height)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestDecodeImageFrameSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height uint32
+		wantErr       bool
+	}{
+		{name: "actual size", width: 1, height: 1},
+		{name: "huge header", width: 65535, height: 65535, wantErr: true},
+		{name: "tall header", width: 1, height: 1<<20 + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := decodeImageFrame(bytes.NewReader(encodePNG(t, tt.width, tt.height)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeImageFrame() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(frame) != matrixWidth*matrixHeight {
+				t.Errorf("decodeImageFrame() returned %d pixels, want %d", len(frame), matrixWidth*matrixHeight)
+			}
+		})
+	}
+}
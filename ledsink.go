@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LedSink receives the frames shown on a device: the device itself, or a
+// simulated one when Simulate is set.
+type LedSink interface {
+	Update(ctx context.Context, fb *Framebuffer) error
+}
+
+// Simulate replaces devices with simulated ones that show frames locally
+// instead of sending them over the network, for developing animations
+// without a cube. Frames are written as PNG files to SimulationDir, or
+// printed to stdout as colored blocks when it is empty.
+var (
+	Simulate      bool
+	SimulationDir string
+)
+
+// newSimulatedSink returns a simulated device for deviceLocation writing
+// frames as configured by SimulationDir.
+func newSimulatedSink(deviceLocation string) *simulatedSink {
+	return &simulatedSink{deviceLocation: deviceLocation, dir: SimulationDir, out: os.Stdout}
+}
+
+// deviceSink sends frames to the device over a kept-open connection.
+type deviceSink struct {
+	conn *DeviceConn
+}
+
+// Update sends fb. update_leds is not waited on, so a device rejecting an
+// earlier frame, typically for exceeding its command quota, is reported by
+// the next Update instead.
+func (s deviceSink) Update(ctx context.Context, fb *Framebuffer) error {
+	if err := s.conn.UpdateFramebuffer(ctx, fb); err != nil {
+		return err
+	}
+	if err := s.conn.TakeRejection(); err != nil {
+		return fmt.Errorf("device rejected a frame: %w", err)
+	}
+	return nil
+}
+
+// simulatedSink is a device without hardware. With dir set every frame
+// replaces a PNG named after the device location, so an image viewer that
+// reloads on change shows the animation; otherwise frames are printed to
+// out. Encoding settings such as white balance are not applied.
+type simulatedSink struct {
+	deviceLocation string
+	dir            string
+	out            io.Writer
+}
+
+// simulatedPNGScale is how many image pixels show one LED in simulated
+// PNG frames.
+const simulatedPNGScale = 16
+
+func (s *simulatedSink) Update(_ context.Context, fb *Framebuffer) error {
+	if s.dir == "" {
+		_, err := fmt.Fprintf(s.out, "%s\n%s", s.deviceLocation, fb)
+		return err
+	}
+
+	data, err := fb.PNG(simulatedPNGScale)
+	if err != nil {
+		return err
+	}
+	name := strings.NewReplacer("yeelight://", "", ":", "_", "/", "_").Replace(s.deviceLocation) + ".png"
+	path := filepath.Join(s.dir, name)
+	// Write to a temporary file first so viewers never see a partial frame.
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write simulated frame: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write simulated frame: %w", err)
+	}
+	return nil
+}
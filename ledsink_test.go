@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// quotaDevice accepts the first frames update_leds commands silently and
+// rejects the rest, like a device whose command quota ran out.
+func quotaDevice(frames int) func(cmd CommandRequest) []string {
+	var mu sync.Mutex
+	received := 0
+	return func(cmd CommandRequest) []string {
+		mu.Lock()
+		defer mu.Unlock()
+		received++
+		if received > frames {
+			return replyError(cmd)
+		}
+		return nil
+	}
+}
+
+// rejectionPending reports whether conn holds a rejection not yet taken.
+func rejectionPending(conn *DeviceConn) bool {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.rejection != nil
+}
+
+func TestDeviceSinkReportsRejectedFrames(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted int
+		// wantErrs is whether each update reports a rejection. A rejected
+		// frame is reported by the update after it.
+		wantErrs []bool
+	}{
+		{name: "accepted frames", accepted: 4, wantErrs: []bool{false, false, false, false}},
+		{name: "rejected frames", accepted: 1, wantErrs: []bool{false, false, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, quotaDevice(tt.accepted))
+			conn := NewDeviceConn(device.Info())
+			t.Cleanup(func() { conn.Close() })
+			sink := deviceSink{conn: conn}
+			fb := NewMatrixFramebuffer()
+
+			for i, wantErr := range tt.wantErrs {
+				waitFor(t, "the previous frames", func() bool { return len(device.Commands()) == i })
+				if wantErr {
+					waitFor(t, "the rejection", func() bool { return rejectionPending(conn) })
+				}
+
+				err := sink.Update(context.Background(), fb)
+				var deviceErr *DeviceError
+				if errors.As(err, &deviceErr) != wantErr {
+					t.Errorf("Update() of frame %d error = %v, want rejection %v", i+1, err, wantErr)
+				}
+			}
+		})
+	}
+}
@@ -24,6 +24,28 @@ func run(ctx context.Context) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	logger, err := NewLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to configure logging: %w", err)
+	}
+	slog.SetDefault(logger)
+
+	SetAnimationCacheSize(cfg.AnimationCacheSize)
+	reachabilityCache = NewReachabilityCache(cfg.ReachabilityTTL)
+	if boundsErr := SetAnimationFPSBounds(cfg.AnimationMinFPS, cfg.AnimationMaxFPS); boundsErr != nil {
+		return fmt.Errorf("invalid ANIMATION_MIN_FPS or ANIMATION_MAX_FPS: %w", boundsErr)
+	}
+	CommandTimeout = cfg.CommandTimeout
+	DiscoveryTimeout = cfg.DiscoveryTimeout
+	DiscoveryInterface = cfg.DiscoveryIface
+	Simulate = cfg.Simulate
+	SimulationDir = cfg.SimulationDir
+	if Simulate && SimulationDir != "" {
+		if mkdirErr := os.MkdirAll(SimulationDir, 0o755); mkdirErr != nil {
+			return fmt.Errorf("failed to create simulation directory: %w", mkdirErr)
+		}
+	}
+
 	db, err := InitDB(ctx, cfg.ServerDBPath)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
@@ -38,9 +60,13 @@ func run(ctx context.Context) error {
 		return fmt.Errorf("failed to run migrations: %w", migrationErr)
 	}
 
+	if loadErr := LoadWhiteBalances(ctx, db); loadErr != nil {
+		return loadErr
+	}
+
 	var wg sync.WaitGroup
 	wg.Go(func() {
-		if serverErr := StartServer(ctx, db, cfg.ServerPort); serverErr != nil {
+		if serverErr := StartServer(ctx, db, cfg); serverErr != nil {
 			slog.Error("Server error", "error", serverErr)
 			os.Exit(1)
 		}
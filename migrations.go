@@ -15,24 +15,33 @@ import (
 var migrationsFS embed.FS
 
 func RunMigrations(db *sql.DB) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// newMigrate returns a migrate instance applying the embedded migrations to db.
+func newMigrate(db *sql.DB) (*migrate.Migrate, error) {
 	sourceDriver, sourceErr := iofs.New(migrationsFS, "migrations")
 	if sourceErr != nil {
-		return fmt.Errorf("failed to create migration source: %w", sourceErr)
+		return nil, fmt.Errorf("failed to create migration source: %w", sourceErr)
 	}
 
 	databaseDriver, dbErr := sqlite.WithInstance(db, &sqlite.Config{})
 	if dbErr != nil {
-		return fmt.Errorf("failed to create database driver: %w", dbErr)
+		return nil, fmt.Errorf("failed to create database driver: %w", dbErr)
 	}
 
 	m, migrateErr := migrate.NewWithInstance("iofs", sourceDriver, "sqlite", databaseDriver)
 	if migrateErr != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", migrateErr)
+		return nil, fmt.Errorf("failed to create migrate instance: %w", migrateErr)
 	}
-
-	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	return nil
+	return m, nil
 }
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS device_settings (
+    device_location TEXT PRIMARY KEY,
+    white_balance_r REAL NOT NULL DEFAULT 1.0,
+    white_balance_g REAL NOT NULL DEFAULT 1.0,
+    white_balance_b REAL NOT NULL DEFAULT 1.0,
+    updated_at TEXT NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_revisions_animation_stack;
+DROP TABLE IF EXISTS animation_revisions;
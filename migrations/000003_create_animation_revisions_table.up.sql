@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS animation_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    animation_id TEXT NOT NULL REFERENCES saved_animations(id) ON DELETE CASCADE,
+    stack TEXT NOT NULL CHECK (stack IN ('undo', 'redo')),
+    name TEXT NOT NULL,
+    frames_json TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_revisions_animation_stack ON animation_revisions(animation_id, stack, id);
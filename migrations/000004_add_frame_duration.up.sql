@@ -0,0 +1 @@
+ALTER TABLE saved_animations ADD COLUMN frame_duration_ms INTEGER NOT NULL DEFAULT 1000;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS devices (
+    id TEXT PRIMARY KEY,
+    location TEXT NOT NULL,
+    model TEXT NOT NULL,
+    name TEXT NOT NULL,
+    last_seen TEXT NOT NULL
+);
+
+CREATE INDEX idx_devices_last_seen ON devices(last_seen DESC);
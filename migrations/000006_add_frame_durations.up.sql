@@ -0,0 +1 @@
+ALTER TABLE saved_animations ADD COLUMN frame_durations_json TEXT NOT NULL DEFAULT '';
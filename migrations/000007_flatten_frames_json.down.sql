@@ -0,0 +1,9 @@
+UPDATE saved_animations SET frames_json = (
+    SELECT json_group_array(json_array(json(value)) ORDER BY key)
+    FROM json_each(saved_animations.frames_json)
+);
+
+UPDATE animation_revisions SET frames_json = (
+    SELECT json_group_array(json_array(json(value)) ORDER BY key)
+    FROM json_each(animation_revisions.frames_json)
+);
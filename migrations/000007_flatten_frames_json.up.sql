@@ -0,0 +1,11 @@
+-- Frames used to be stored as [[[pixels]], ...], each frame wrapped in a
+-- redundant single-element array. Unwrap them to [[pixels], ...].
+UPDATE saved_animations SET frames_json = (
+    SELECT json_group_array(json(json_extract(value, '$[0]')) ORDER BY key)
+    FROM json_each(saved_animations.frames_json)
+);
+
+UPDATE animation_revisions SET frames_json = (
+    SELECT json_group_array(json(json_extract(value, '$[0]')) ORDER BY key)
+    FROM json_each(animation_revisions.frames_json)
+);
@@ -0,0 +1 @@
+ALTER TABLE saved_animations DROP COLUMN tags_json;
@@ -0,0 +1 @@
+ALTER TABLE saved_animations ADD COLUMN tags_json TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+ALTER TABLE animation_revisions DROP COLUMN frame_durations_json;
+ALTER TABLE animation_revisions DROP COLUMN frame_duration_ms;
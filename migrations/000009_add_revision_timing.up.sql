@@ -0,0 +1,2 @@
+ALTER TABLE animation_revisions ADD COLUMN frame_duration_ms INTEGER;
+ALTER TABLE animation_revisions ADD COLUMN frame_durations_json TEXT;
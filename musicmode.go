@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// musicModeAcceptTimeout bounds how long EnableMusicMode waits for the device
+// to connect back.
+var musicModeAcceptTimeout = 5 * time.Second
+
+var errMusicModeClosed = errors.New("music mode connection closed")
+
+// EnableMusicMode switches the device to music mode, in which it connects
+// back to a listener on hostIP and accepts commands on that connection
+// without its usual rate limit. The returned connection is meant for
+// streaming commands with SendNoResponse or UpdateLeds: the device does not
+// answer commands in music mode, and once the connection drops it is not
+// re-established. Call DisableMusicMode and close the connection when done.
+// If the device never connects back, music mode is disabled again before the
+// error is returned.
+func EnableMusicMode(device *DeviceInfo, hostIP string) (*DeviceConn, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(hostIP, "0"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for music mode: %w", err)
+	}
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	response, err := SendCommand(device, "set_music", []any{1, hostIP, port})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable music mode: %w", err)
+	}
+	if okErr := expectOK(response); okErr != nil {
+		return nil, okErr
+	}
+
+	deadline := time.Now().Add(musicModeAcceptTimeout)
+	if deadlineErr := listener.(*net.TCPListener).SetDeadline(deadline); deadlineErr != nil {
+		err := fmt.Errorf("failed to set accept deadline: %w", deadlineErr)
+		return nil, errors.Join(err, DisableMusicMode(device))
+	}
+	conn, err := listener.Accept()
+	if err != nil {
+		err = fmt.Errorf("device did not connect back on port %d: %w", port, err)
+		return nil, errors.Join(err, DisableMusicMode(device))
+	}
+
+	musicConn := NewDeviceConn(device)
+	musicConn.dial = func(context.Context) (net.Conn, error) {
+		return nil, errMusicModeClosed
+	}
+	musicConn.mu.Lock()
+	musicConn.attach(conn)
+	musicConn.mu.Unlock()
+	return musicConn, nil
+}
+
+// DisableMusicMode switches the device back to regular command handling.
+func DisableMusicMode(device *DeviceInfo) error {
+	response, err := SendCommand(device, "set_music", []any{0})
+	if err != nil {
+		return fmt.Errorf("failed to disable music mode: %w", err)
+	}
+
+	return expectOK(response)
+}
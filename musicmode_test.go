@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// connectingBack acknowledges set_music and, when enabling, connects to the
+// host and port it was given like a device entering music mode.
+func connectingBack(t *testing.T) func(cmd CommandRequest) []string {
+	var mu sync.Mutex
+	var conns []net.Conn
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+	return func(cmd CommandRequest) []string {
+		if cmd.Method == "set_music" && cmd.Params[0] == float64(1) {
+			host, _ := cmd.Params[1].(string)
+			port, _ := cmd.Params[2].(float64)
+			go func() {
+				conn, err := net.Dial("tcp", net.JoinHostPort(host, fmt.Sprint(port)))
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				conns = append(conns, conn)
+			}()
+		}
+		return replyOK(cmd)
+	}
+}
+
+// musicModeCalls returns the first parameter of every set_music command.
+func musicModeCalls(commands []CommandRequest) []any {
+	var calls []any
+	for _, cmd := range commands {
+		if cmd.Method == "set_music" {
+			calls = append(calls, cmd.Params[0])
+		}
+	}
+	return calls
+}
+
+func TestEnableMusicMode(t *testing.T) {
+	previous := musicModeAcceptTimeout
+	musicModeAcceptTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { musicModeAcceptTimeout = previous })
+
+	tests := []struct {
+		name          string
+		reply         func(t *testing.T) func(cmd CommandRequest) []string
+		wantErr       bool
+		wantDeviceErr bool
+		wantCalls     []any
+	}{
+		{name: "device connects back", reply: connectingBack, wantCalls: []any{float64(1)}},
+		{
+			name:      "device never connects back",
+			reply:     func(*testing.T) func(cmd CommandRequest) []string { return replyOK },
+			wantErr:   true,
+			wantCalls: []any{float64(1), float64(0)},
+		},
+		{
+			name:          "device refuses music mode",
+			reply:         func(*testing.T) func(cmd CommandRequest) []string { return replyError },
+			wantErr:       true,
+			wantDeviceErr: true,
+			wantCalls:     []any{float64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply(t))
+			conn, err := EnableMusicMode(device.Info(), "127.0.0.1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnableMusicMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if conn != nil {
+				conn.Close()
+			}
+			var deviceErr *DeviceError
+			if errors.As(err, &deviceErr) != tt.wantDeviceErr {
+				t.Errorf("EnableMusicMode() error = %v, want device error %v", err, tt.wantDeviceErr)
+			}
+			if got := musicModeCalls(device.Commands()); !slices.Equal(got, tt.wantCalls) {
+				t.Errorf("set_music sent with %v, want %v", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"cubik/api"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+// previewMaxMessageBytes bounds a preview message; a full frame of JSON
+// pixels takes a few kilobytes.
+const previewMaxMessageBytes = 16 << 10
+
+// previewServer serves previewHandler to pages from this server itself or
+// from origins listed in allowedOrigins. The preview drives the device, so
+// unlike a plain websocket.Handler it refuses other origins, which would
+// otherwise let any website a user visits open the socket from their
+// browser. For the same reason "*", which opens the REST API to any origin,
+// is ignored here. Requests without an Origin header come from non-browser
+// clients and are accepted.
+func previewServer(allowedOrigins []string) http.Handler {
+	listed := slices.DeleteFunc(slices.Clone(allowedOrigins), func(origin string) bool {
+		return strings.TrimSpace(origin) == "*"
+	})
+	allowed := originChecker(listed)
+	return websocket.Server{
+		Handler: previewHandler,
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			origin := r.Header.Get("Origin")
+			if origin == "" || allowed(origin) {
+				return nil
+			}
+			if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+				return nil
+			}
+			return fmt.Errorf("origin %q is not allowed", origin)
+		},
+	}
+}
+
+// previewHandler serves the live preview WebSocket at
+// GET /api/device/preview?device_location=yeelight://IP:PORT. Every message
+// is a single api.AnimationFrame that is shown on the device as soon as it
+// arrives, so editors can see a frame on the cube without saving it. Any
+// animation running on the device is stopped when the socket opens. Invalid
+// frames are answered with an {"error": ...} message and otherwise ignored.
+// Closing the socket leaves the last frame displayed.
+func previewHandler(ws *websocket.Conn) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = previewMaxMessageBytes
+
+	deviceLocation := ws.Request().URL.Query().Get("device_location")
+	if _, err := parseLocation(deviceLocation); err != nil {
+		sendPreviewError(ws, err)
+		return
+	}
+
+	StopDeviceAnimation(deviceLocation)
+
+	var sink LedSink
+	if Simulate {
+		sink = newSimulatedSink(deviceLocation)
+	} else {
+		device := &DeviceInfo{Location: deviceLocation}
+		if err := ActivateFxMode(device); err != nil {
+			sendPreviewError(ws, err)
+			return
+		}
+
+		conn := NewDeviceConn(device)
+		defer conn.Close()
+		sink = deviceSink{conn: conn}
+	}
+
+	ctx := ws.Request().Context()
+	fb := NewMatrixFramebuffer()
+	for {
+		var message []byte
+		if err := websocket.Message.Receive(ws, &message); err != nil {
+			if !errors.Is(err, io.EOF) {
+				slog.Warn("Preview connection error", "device", deviceLocation, "error", err)
+			}
+			return
+		}
+
+		if err := showPreviewFrame(ctx, sink, fb, deviceLocation, message); err != nil {
+			sendPreviewError(ws, err)
+		}
+	}
+}
+
+func showPreviewFrame(
+	ctx context.Context,
+	sink LedSink,
+	fb *Framebuffer,
+	deviceLocation string,
+	message []byte,
+) error {
+	var frame api.AnimationFrame
+	if err := frame.UnmarshalJSON(message); err != nil {
+		return fmt.Errorf("invalid frame: %w", err)
+	}
+	if err := frame.Validate(); err != nil {
+		return fmt.Errorf("invalid frame: %w", err)
+	}
+
+	colors := ConvertAPIFrameToColors(frame)
+	if err := validateFrames([][]Color{colors}, len(fb.Pixels)); err != nil {
+		return err
+	}
+
+	copy(fb.Pixels, colors)
+	wb := DeviceWhiteBalance(deviceLocation)
+	fb.WhiteBalance = &wb
+	return sink.Update(ctx, fb)
+}
+
+func sendPreviewError(ws *websocket.Conn, err error) {
+	payload := api.Error{Error: err.Error()}
+	data, marshalErr := payload.MarshalJSON()
+	if marshalErr != nil {
+		return
+	}
+	_ = websocket.Message.Send(ws, string(data))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestPreviewServerOrigins(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		wantErr        bool
+	}{
+		{name: "no origin", allowedOrigins: []string{"*"}},
+		{name: "same host", allowedOrigins: []string{"*"}, origin: "http://cubik.local:9080"},
+		{
+			name:           "listed origin",
+			allowedOrigins: []string{" http://localhost:5173"},
+			origin:         "http://localhost:5173",
+		},
+		{
+			name:           "unlisted origin",
+			allowedOrigins: []string{"http://localhost:5173"},
+			origin:         "https://evil.example",
+			wantErr:        true,
+		},
+		{
+			name:           "wildcard is not an allowlist",
+			allowedOrigins: []string{"*"},
+			origin:         "https://evil.example",
+			wantErr:        true,
+		},
+		{
+			name:           "wildcard next to listed origins",
+			allowedOrigins: []string{"*", "http://localhost:5173"},
+			origin:         "https://evil.example",
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, ok := previewServer(tt.allowedOrigins).(websocket.Server)
+			if !ok {
+				t.Fatal("previewServer() is not a websocket.Server")
+			}
+			req := httptest.NewRequest(http.MethodGet, "http://cubik.local:9080/api/device/preview", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			if err := server.Handshake(&websocket.Config{}, req); (err != nil) != tt.wantErr {
+				t.Errorf("Handshake() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
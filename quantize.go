@@ -0,0 +1,153 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// colorBox is a set of distinct colors handled as one unit by median cut.
+type colorBox struct {
+	colors []Color
+	counts []int
+}
+
+// QuantizeColors reduces the frames to at most maxColors distinct colors
+// using median cut: the set of colors is repeatedly split at the weighted
+// median of its widest channel, and every color is replaced by the average
+// of its final box. Frames that already fit are returned as copies.
+func QuantizeColors(frames [][]Color, maxColors int) [][]Color {
+	maxColors = max(1, maxColors)
+
+	histogram := make(map[Color]int)
+	for _, frame := range frames {
+		for _, c := range frame {
+			histogram[c]++
+		}
+	}
+
+	mapping := make(map[Color]Color, len(histogram))
+	if len(histogram) <= maxColors {
+		for c := range histogram {
+			mapping[c] = c
+		}
+	} else {
+		initial := colorBox{}
+		for c, count := range histogram {
+			initial.colors = append(initial.colors, c)
+			initial.counts = append(initial.counts, count)
+		}
+
+		boxes := []colorBox{initial}
+		for len(boxes) < maxColors {
+			widest, channel, spread := -1, 0, 0
+			for i, box := range boxes {
+				if len(box.colors) < 2 {
+					continue
+				}
+				if boxChannel, boxSpread := box.widestChannel(); boxSpread > spread {
+					widest, channel, spread = i, boxChannel, boxSpread
+				}
+			}
+			if widest < 0 {
+				break
+			}
+
+			low, high := boxes[widest].split(channel)
+			boxes[widest] = low
+			boxes = append(boxes, high)
+		}
+
+		for _, box := range boxes {
+			average := box.average()
+			for _, c := range box.colors {
+				mapping[c] = average
+			}
+		}
+	}
+
+	quantized := make([][]Color, len(frames))
+	for i, frame := range frames {
+		quantized[i] = make([]Color, len(frame))
+		for j, c := range frame {
+			quantized[i][j] = mapping[c]
+		}
+	}
+	return quantized
+}
+
+func channelValue(c Color, channel int) uint8 {
+	switch channel {
+	case 0:
+		return c.R
+	case 1:
+		return c.G
+	default:
+		return c.B
+	}
+}
+
+// widestChannel returns the channel (0 - R, 1 - G, 2 - B) with the largest
+// value range in the box, and that range.
+func (b colorBox) widestChannel() (int, int) {
+	bestChannel, bestSpread := 0, -1
+	for channel := range 3 {
+		lo, hi := uint8(math.MaxUint8), uint8(0)
+		for _, c := range b.colors {
+			v := channelValue(c, channel)
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		if spread := int(hi) - int(lo); spread > bestSpread {
+			bestChannel, bestSpread = channel, spread
+		}
+	}
+	return bestChannel, bestSpread
+}
+
+// split sorts the box along channel and cuts it at the weighted median so
+// both halves hold roughly the same number of pixels.
+func (b colorBox) split(channel int) (colorBox, colorBox) {
+	order := make([]int, len(b.colors))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(x, y int) int {
+		return int(channelValue(b.colors[x], channel)) - int(channelValue(b.colors[y], channel))
+	})
+
+	sorted := colorBox{colors: make([]Color, len(order)), counts: make([]int, len(order))}
+	total := 0
+	for i, idx := range order {
+		sorted.colors[i] = b.colors[idx]
+		sorted.counts[i] = b.counts[idx]
+		total += b.counts[idx]
+	}
+
+	cut, running := 1, 0
+	for i := 0; i < len(sorted.colors)-1; i++ {
+		running += sorted.counts[i]
+		cut = i + 1
+		if running*2 >= total {
+			break
+		}
+	}
+
+	return colorBox{colors: sorted.colors[:cut], counts: sorted.counts[:cut]},
+		colorBox{colors: sorted.colors[cut:], counts: sorted.counts[cut:]}
+}
+
+// average returns the pixel-weighted mean color of the box.
+func (b colorBox) average() Color {
+	var r, g, bl, total float64
+	for i, c := range b.colors {
+		weight := float64(b.counts[i])
+		r += float64(c.R) * weight
+		g += float64(c.G) * weight
+		bl += float64(c.B) * weight
+		total += weight
+	}
+	return Color{
+		R: uint8(math.Round(r / total)),
+		G: uint8(math.Round(g / total)),
+		B: uint8(math.Round(bl / total)),
+	}
+}
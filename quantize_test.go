@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// gradientFrames returns frames whose pixels form a smooth red ramp with
+// count distinct colors.
+func gradientFrames(count int) [][]Color {
+	frame := make([]Color, count)
+	for i := range frame {
+		frame[i] = Color{R: uint8(i * 255 / max(count-1, 1)), G: 40, B: uint8(i % 7)}
+	}
+	return [][]Color{frame, slices.Clone(frame)}
+}
+
+// distinctColors returns the number of distinct colors in frames.
+func distinctColors(frames [][]Color) int {
+	seen := make(map[Color]bool)
+	for _, frame := range frames {
+		for _, c := range frame {
+			seen[c] = true
+		}
+	}
+	return len(seen)
+}
+
+func TestQuantizeColors(t *testing.T) {
+	tests := []struct {
+		name      string
+		frames    [][]Color
+		maxColors int
+		// wantMax is the most colors the result may have.
+		wantMax       int
+		wantUnchanged bool
+	}{
+		{name: "already within the limit", frames: gradientFrames(4), maxColors: 8, wantMax: 8, wantUnchanged: true},
+		{name: "reduced to the limit", frames: gradientFrames(100), maxColors: 16, wantMax: 16},
+		{name: "single color", frames: gradientFrames(100), maxColors: 1, wantMax: 1},
+		{name: "limit below one means one", frames: gradientFrames(10), maxColors: 0, wantMax: 1},
+		{name: "no frames", frames: nil, maxColors: 4, wantMax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := make([][]Color, len(tt.frames))
+			for i, frame := range tt.frames {
+				original[i] = slices.Clone(frame)
+			}
+
+			quantized := QuantizeColors(tt.frames, tt.maxColors)
+			if got := distinctColors(quantized); got > tt.wantMax {
+				t.Errorf("QuantizeColors() has %d colors, want at most %d", got, tt.wantMax)
+			}
+			if len(quantized) != len(tt.frames) {
+				t.Fatalf("QuantizeColors() returned %d frames, want %d", len(quantized), len(tt.frames))
+			}
+			for i := range quantized {
+				if len(quantized[i]) != len(tt.frames[i]) {
+					t.Errorf("frame %d has %d pixels, want %d", i, len(quantized[i]), len(tt.frames[i]))
+				}
+				if !slices.Equal(tt.frames[i], original[i]) {
+					t.Errorf("QuantizeColors() modified input frame %d", i)
+				}
+				if tt.wantUnchanged && !slices.Equal(quantized[i], original[i]) {
+					t.Errorf("QuantizeColors() changed frame %d, which already fit", i)
+				}
+			}
+		})
+	}
+}
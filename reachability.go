@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type reachabilityEntry struct {
+	reachable  bool
+	checkedAt  time.Time
+	refreshing bool
+	// status holds the properties read by the exchange that verified
+	// reachability, when it was a status query.
+	status map[string]string
+}
+
+// ReachabilityCache remembers whether devices answered recently so that
+// dashboards polling many cubes do not dial each of them on every request.
+type ReachabilityCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*reachabilityEntry
+	probe   func(ctx context.Context, deviceLocation string) bool
+}
+
+func NewReachabilityCache(ttl time.Duration) *ReachabilityCache {
+	return &ReachabilityCache{
+		ttl:     ttl,
+		entries: make(map[string]*reachabilityEntry),
+		probe:   probeDevice,
+	}
+}
+
+var reachabilityCache = NewReachabilityCache(5 * time.Second)
+
+// probeDevice reports whether a TCP connection to the device can be opened.
+func probeDevice(ctx context.Context, deviceLocation string) bool {
+	conn, err := dialDevice(ctx, &DeviceInfo{Location: deviceLocation})
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Check returns whether the device is reachable and when that was last
+// verified. Results younger than the TTL are returned as is. Older results
+// are returned too, while a background probe refreshes them. Devices never
+// checked before are probed synchronously.
+func (c *ReachabilityCache) Check(ctx context.Context, deviceLocation string) (bool, time.Time) {
+	c.mu.Lock()
+	entry, exists := c.entries[deviceLocation]
+	if exists {
+		reachable, checkedAt := entry.reachable, entry.checkedAt
+		if time.Since(checkedAt) >= c.ttl && !entry.refreshing {
+			entry.refreshing = true
+			go c.refresh(deviceLocation)
+		}
+		c.mu.Unlock()
+		return reachable, checkedAt
+	}
+	c.mu.Unlock()
+
+	reachable := c.probe(ctx, deviceLocation)
+	c.Record(deviceLocation, reachable)
+	return reachable, time.Now()
+}
+
+// Fresh returns the cached reachability if it was verified within the TTL,
+// along with the status properties recorded with RecordStatus, if any. The
+// last result reports whether such a fresh entry exists.
+func (c *ReachabilityCache) Fresh(deviceLocation string) (bool, map[string]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[deviceLocation]
+	if !exists || time.Since(entry.checkedAt) >= c.ttl {
+		return false, nil, false
+	}
+	return entry.reachable, entry.status, true
+}
+
+// Record stores the outcome of any exchange with the device, so regular
+// commands keep the cache warm without extra probes.
+func (c *ReachabilityCache) Record(deviceLocation string, reachable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[deviceLocation] = &reachabilityEntry{reachable: reachable, checkedAt: time.Now()}
+}
+
+// RecordStatus records the device as reachable and caches the status
+// properties it just reported, so status requests within the TTL are
+// answered without querying the device again.
+func (c *ReachabilityCache) RecordStatus(deviceLocation string, status map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[deviceLocation] = &reachabilityEntry{reachable: true, checkedAt: time.Now(), status: status}
+}
+
+// ForgetStatus drops the cached status properties of the device after its
+// state was changed, keeping the reachability.
+func (c *ReachabilityCache) ForgetStatus(deviceLocation string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, exists := c.entries[deviceLocation]; exists {
+		entry.status = nil
+	}
+}
+
+func (c *ReachabilityCache) refresh(deviceLocation string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.ttl+CommandTimeout)
+	defer cancel()
+	c.Record(deviceLocation, c.probe(ctx, deviceLocation))
+}
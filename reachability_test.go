@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"cubik/api"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReachabilityCacheCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		// age is how old the cached result is; negative means none.
+		age            time.Duration
+		wantSyncProbes int32
+		wantRefresh    bool
+	}{
+		{name: "never checked", age: -1, wantSyncProbes: 1},
+		{name: "fresh result", age: 0},
+		{name: "stale result", age: 2 * time.Minute, wantRefresh: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probes atomic.Int32
+			cache := NewReachabilityCache(time.Minute)
+			cache.probe = func(context.Context, string) bool {
+				probes.Add(1)
+				return true
+			}
+			const location = "yeelight://192.168.1.10:55443"
+			if tt.age >= 0 {
+				cache.entries[location] = &reachabilityEntry{checkedAt: time.Now().Add(-tt.age)}
+			}
+
+			reachable, _ := cache.Check(context.Background(), location)
+			if got := probes.Load(); got != tt.wantSyncProbes {
+				t.Fatalf("Check() probed %d times before returning, want %d", got, tt.wantSyncProbes)
+			}
+			if tt.age >= 0 && reachable {
+				t.Error("Check() did not return the cached result")
+			}
+			if tt.wantRefresh {
+				waitFor(t, "the background refresh", func() bool {
+					reachable, _, ok := cache.Fresh(location)
+					return ok && reachable
+				})
+			}
+		})
+	}
+}
+
+// statusDevice answers status and capability queries of GetDeviceStatus.
+func statusDevice(cmd CommandRequest) []string {
+	if cmd.Method == "get_prop" && cmd.Params[0] == "power" {
+		return replyProps("on", "50", "1")(cmd)
+	}
+	return replyProps("16711680", "4000", "")(cmd)
+}
+
+func TestGetDeviceStatusCache(t *testing.T) {
+	previous := reachabilityCache
+	t.Cleanup(func() { reachabilityCache = previous })
+
+	tests := []struct {
+		name string
+		// between runs between the two status requests.
+		between     func(h *APIHandler, device *fakeDevice)
+		wantQueries int
+		wantErr     bool
+	}{
+		{name: "second request is cached", between: func(*APIHandler, *fakeDevice) {}, wantQueries: 1},
+		{
+			name: "changing the device drops the cached status",
+			between: func(h *APIHandler, device *fakeDevice) {
+				_, _ = h.SetDevicePower(context.Background(), &api.SetDevicePowerRequest{
+					DeviceLocation: device.Location(),
+					Power:          false,
+				})
+			},
+			wantQueries: 2,
+		},
+		{
+			name: "recently unreachable is not dialed",
+			between: func(_ *APIHandler, device *fakeDevice) {
+				reachabilityCache.Record(device.Location(), false)
+			},
+			wantQueries: 1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reachabilityCache = NewReachabilityCache(time.Minute)
+			h := &APIHandler{db: newTestDB(t)}
+			device := newFakeDevice(t, statusDevice)
+			params := api.GetDeviceStatusParams{DeviceLocation: device.Location()}
+
+			if _, ok := mustGetDeviceStatus(t, h, params).(*api.DeviceStatusResponse); !ok {
+				t.Fatal("first GetDeviceStatus() did not return a status")
+			}
+			tt.between(h, device)
+			_, gotStatus := mustGetDeviceStatus(t, h, params).(*api.DeviceStatusResponse)
+			if gotStatus == tt.wantErr {
+				t.Errorf("second GetDeviceStatus() returned a status = %v, want %v", gotStatus, !tt.wantErr)
+			}
+
+			queries := 0
+			for _, cmd := range device.Commands() {
+				if cmd.Method == "get_prop" && cmd.Params[0] == "power" {
+					queries++
+				}
+			}
+			if queries != tt.wantQueries {
+				t.Errorf("device was queried %d times, want %d", queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+func mustGetDeviceStatus(t *testing.T, h *APIHandler, params api.GetDeviceStatusParams) api.GetDeviceStatusRes {
+	t.Helper()
+	res, err := h.GetDeviceStatus(context.Background(), params)
+	if err != nil {
+		t.Fatalf("GetDeviceStatus() error = %v", err)
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// frameScheduler computes frame deadlines from a fixed start time rather than
+// sleeping a fixed interval after each frame, so time spent encoding and
+// sending a frame does not accumulate into drift. Devices started at the same
+// time with the same interval therefore stay in step.
+type frameScheduler struct {
+	start    time.Time
+	interval time.Duration
+	frame    int64
+	last     time.Time
+}
+
+func newFrameScheduler(start time.Time, interval time.Duration) *frameScheduler {
+	return &frameScheduler{start: start, interval: interval, last: start}
+}
+
+// Next returns the deadline of the next frame. When a frame overran its slot
+// the missed deadlines are skipped instead of being played in a burst.
+func (s *frameScheduler) Next(now time.Time) time.Time {
+	s.frame++
+	deadline := s.start.Add(time.Duration(s.frame) * s.interval)
+	if deadline.Before(now) {
+		s.frame = int64(now.Sub(s.start)/s.interval) + 1
+		deadline = s.start.Add(time.Duration(s.frame) * s.interval)
+	}
+	s.last = deadline
+	return deadline
+}
+
+// NextAfter returns the deadline hold after the previous one, for frames with
+// individual durations. A missed deadline is replaced by now rather than
+// caught up on.
+func (s *frameScheduler) NextAfter(now time.Time, hold time.Duration) time.Time {
+	s.frame++
+	deadline := s.last.Add(hold)
+	if deadline.Before(now) {
+		deadline = now
+	}
+	s.last = deadline
+	return deadline
+}
+
+// fpsMeter measures the rate at which frames are actually delivered.
+type fpsMeter struct {
+	start  time.Time
+	frames atomic.Int64
+	fps    atomic.Uint64
+}
+
+func newFPSMeter(start time.Time) *fpsMeter {
+	return &fpsMeter{start: start}
+}
+
+// Frame records a delivered frame at now.
+func (m *fpsMeter) Frame(now time.Time) {
+	frames := m.frames.Add(1)
+	elapsed := now.Sub(m.start).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	m.fps.Store(math.Float64bits(float64(frames) / elapsed))
+}
+
+// FPS returns the average number of frames delivered per second.
+func (m *fpsMeter) FPS() float64 {
+	return math.Float64frombits(m.fps.Load())
+}
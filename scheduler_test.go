@@ -0,0 +1,118 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFrameSchedulerNext(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	interval := 100 * time.Millisecond
+
+	tests := []struct {
+		name string
+		// work is how long each frame takes to encode and send.
+		work []time.Duration
+		// want are the deadlines, as offsets from start.
+		want []time.Duration
+	}{
+		{
+			name: "instant frames",
+			work: []time.Duration{0, 0, 0},
+			want: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name: "slow frames do not accumulate drift",
+			work: []time.Duration{30 * time.Millisecond, 90 * time.Millisecond, 45 * time.Millisecond},
+			want: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name: "an overrun skips missed deadlines",
+			work: []time.Duration{0, 250 * time.Millisecond, 0},
+			want: []time.Duration{100 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := newFrameScheduler(start, interval)
+			now := start
+			for i, work := range tt.work {
+				now = now.Add(work)
+				deadline := scheduler.Next(now)
+				if got := deadline.Sub(start); got != tt.want[i] {
+					t.Errorf("Next() for frame %d = start+%s, want start+%s", i+1, got, tt.want[i])
+				}
+				// The fake clock sleeps until the deadline.
+				now = deadline
+			}
+		})
+	}
+}
+
+func TestFrameSchedulerNextAfter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		holds []time.Duration
+		work  []time.Duration
+		want  []time.Duration
+	}{
+		{
+			name:  "individual durations",
+			holds: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 50 * time.Millisecond},
+			work:  []time.Duration{20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond},
+			want:  []time.Duration{100 * time.Millisecond, 400 * time.Millisecond, 450 * time.Millisecond},
+		},
+		{
+			name:  "a missed deadline is not caught up on",
+			holds: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+			work:  []time.Duration{0, 150 * time.Millisecond, 0},
+			want:  []time.Duration{100 * time.Millisecond, 250 * time.Millisecond, 350 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := newFrameScheduler(start, 0)
+			now := start
+			for i, hold := range tt.holds {
+				now = now.Add(tt.work[i])
+				deadline := scheduler.NextAfter(now, hold)
+				if got := deadline.Sub(start); got != tt.want[i] {
+					t.Errorf("NextAfter() for frame %d = start+%s, want start+%s", i+1, got, tt.want[i])
+				}
+				now = deadline
+			}
+		})
+	}
+}
+
+func TestFPSMeter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		frames  int
+		spacing time.Duration
+		want    float64
+	}{
+		{name: "no frames", want: 0},
+		{name: "ten per second", frames: 50, spacing: 100 * time.Millisecond, want: 10},
+		{name: "four per second", frames: 8, spacing: 250 * time.Millisecond, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meter := newFPSMeter(start)
+			for i := 1; i <= tt.frames; i++ {
+				meter.Frame(start.Add(time.Duration(i) * tt.spacing))
+			}
+			if got := meter.FPS(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("FPS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,96 +1,418 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
 	"cubik/api"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
+	"net"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
 )
 
 //go:embed front/build/**
 var frontendFS embed.FS
 
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+// corsMiddleware allows cross-origin requests from allowedOrigins. "*"
+// allows any origin; otherwise the request's Origin is echoed back only when
+// it is in the list, and browsers block responses to other origins.
+func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
+	allowed := originChecker(allowedOrigins)
+	allowAny := slices.ContainsFunc(allowedOrigins, func(origin string) bool {
+		return strings.TrimSpace(origin) == "*"
+	})
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if allowAny {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Add("Vary", "Origin")
+				if origin := r.Header.Get("Origin"); origin != "" && allowed(origin) {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+// originChecker returns a function reporting whether a request Origin is in
+// allowedOrigins, where "*" allows any origin.
+func originChecker(allowedOrigins []string) func(origin string) bool {
+	trimmed := make([]string, len(allowedOrigins))
+	for i, origin := range allowedOrigins {
+		trimmed[i] = strings.TrimSpace(origin)
+	}
+	allowAny := slices.Contains(trimmed, "*")
+	return func(origin string) bool {
+		return allowAny || slices.Contains(trimmed, origin)
+	}
+}
+
+// gzipMinSize is the smallest response, when its length is known up front,
+// worth compressing.
+const gzipMinSize = 1024
+
+// gzipMiddleware compresses responses for clients that accept gzip. It
+// decides once the handler writes the header: already encoded or compressed
+// content, such as images, partial and empty responses, and small responses
+// of known length are passed through unchanged. It must not wrap streaming
+// or hijacked connections.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
 			return
 		}
 
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) == "gzip" && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if shouldGzip(w.Header(), status) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.Header().Del("Accept-Ranges")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Close flushes the compressed stream, if the response was compressed.
+func (w *gzipResponseWriter) Close() error {
+	if w.gz == nil {
+		return nil
+	}
+	return w.gz.Close()
+}
+
+// incompressibleTypes are content types that are already compressed.
+var incompressibleTypes = []string{"image/", "video/", "audio/", "font/woff", "application/zip", "application/gzip"}
+
+func shouldGzip(header http.Header, status int) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent ||
+		status == http.StatusNotModified {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length < gzipMinSize {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxBytesMiddleware fails reading request bodies larger than limit bytes,
+// so an oversized upload is rejected instead of being held in memory.
+func maxBytesMiddleware(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withoutDeadlines lifts the server's read and write timeouts for
+// long-lived connections, such as event streams and WebSockets, which would
+// otherwise be cut off once the timeouts pass.
+func withoutDeadlines(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		if err := errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{})); err != nil {
+			slog.Warn("Failed to clear connection deadlines", "path", r.URL.Path, "error", err)
+		}
 		next.ServeHTTP(w, r)
 	})
 }
 
-func StartServer(ctx context.Context, db *sql.DB, port string) error {
+// untilShutdown ends the request context of a long-lived stream once
+// shuttingDown is done. http.Server.Shutdown waits for active requests
+// without cancelling them, so a stream open until the client leaves would
+// otherwise hold up shutdown forever.
+func untilShutdown(shuttingDown context.Context, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stop := context.AfterFunc(shuttingDown, cancel)
+		defer stop()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// timeoutMiddleware bounds every API operation by timeout. A handler that
+// fails because the deadline passed returns the error, which
+// apiErrorHandler reports as 504 Gateway Timeout. Responses are passed
+// through even when the deadline passed meanwhile: the handler's work is
+// done, and a 504 would invite the client to repeat it.
+func timeoutMiddleware(timeout time.Duration) api.Middleware {
+	return func(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+		ctx, cancel := context.WithTimeout(req.Context, timeout)
+		defer cancel()
+		req.SetContext(ctx)
+
+		resp, err := next(req)
+		if err != nil && errors.Is(err, context.DeadlineExceeded) {
+			return resp, fmt.Errorf("%s timed out after %s: %w", req.OperationID, timeout, err)
+		}
+		return resp, err
+	}
+}
+
+func apiErrorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeJSONError(w, http.StatusGatewayTimeout, err)
+	case errors.As(err, &tooLarge):
+		writeJSONError(w, http.StatusRequestEntityTooLarge, err)
+	default:
+		ogenerrors.DefaultErrorHandler(ctx, w, r, err)
+	}
+}
+
+// writeJSONError responds with status and an {"error": ...} body like the
+// API's own error responses.
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	e := jx.GetEncoder()
+	defer jx.PutEncoder(e)
+	e.Obj(func(e *jx.Encoder) {
+		e.FieldStart("error")
+		e.Str(err.Error())
+	})
+	_, _ = w.Write(e.Bytes())
+}
+
+// Connection limits protecting the server from slow or oversized clients.
+// Event streams and the preview WebSocket are exempt from the read and write
+// timeouts.
+const (
+	serverReadHeaderTimeout = 10 * time.Second
+	serverReadTimeout       = time.Minute
+	serverIdleTimeout       = 2 * time.Minute
+	serverMaxHeaderBytes    = 64 << 10
+	// serverShutdownTimeout bounds waiting for in-flight requests on
+	// shutdown; connections still open afterwards are closed.
+	serverShutdownTimeout = 10 * time.Second
+)
+
+// StartServer serves the API and the frontend on cfg.ServerHost and
+// cfg.ServerPort until ctx is done.
+func StartServer(ctx context.Context, db *sql.DB, cfg *Config) error {
 	handler := &APIHandler{db: db}
-	srv, srvErr := api.NewServer(handler)
+	srv, srvErr := api.NewServer(
+		handler,
+		api.WithMiddleware(timeoutMiddleware(cfg.RequestTimeout)),
+		api.WithErrorHandler(apiErrorHandler),
+	)
 	if srvErr != nil {
 		return fmt.Errorf("failed to create server: %w", srvErr)
 	}
 
+	// Streams end when shutdown starts, see untilShutdown.
+	shuttingDown, stopStreams := context.WithCancel(context.Background())
+	defer stopStreams()
+
+	cors := corsMiddleware(cfg.CORSOrigins)
 	mux := http.NewServeMux()
-	mux.Handle("/api/", corsMiddleware(srv))
+	mux.Handle("/api/", gzipMiddleware(cors(maxBytesMiddleware(cfg.MaxRequestBytes)(srv))))
+	mux.Handle(
+		"GET /api/devices/discover/stream",
+		withoutDeadlines(cors(untilShutdown(shuttingDown, http.HandlerFunc(discoverStreamHandler)))),
+	)
+	mux.Handle(
+		"GET /api/animation/status/stream",
+		withoutDeadlines(cors(untilShutdown(shuttingDown, http.HandlerFunc(animationStatusStreamHandler)))),
+	)
+	mux.Handle("GET /api/device/preview", withoutDeadlines(previewServer(cfg.CORSOrigins)))
 
 	frontendSubFS, subErr := fs.Sub(frontendFS, "front/build")
 	if subErr != nil {
 		return fmt.Errorf("failed to create frontend sub-filesystem: %w", subErr)
 	}
 
-	spaHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		path := strings.TrimPrefix(r.URL.Path, "/")
-		if path == "" {
-			path = "index.html"
+	mux.Handle("/", gzipMiddleware(spaHandler(frontendSubFS)))
+
+	listener, listenErr := net.Listen("tcp", net.JoinHostPort(cfg.ServerHost, cfg.ServerPort))
+	if listenErr != nil {
+		return fmt.Errorf("failed to listen: %w", listenErr)
+	}
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+		ReadTimeout:       serverReadTimeout,
+		// Responses get the request timeout plus time to send them.
+		WriteTimeout:   cfg.RequestTimeout + serverReadTimeout,
+		IdleTimeout:    serverIdleTimeout,
+		MaxHeaderBytes: serverMaxHeaderBytes,
+	}
+	httpServer.RegisterOnShutdown(stopStreams)
+
+	slog.Info("Starting Cubik server", "address", "http://"+listener.Addr().String())
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		slog.Info("Shutting down server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+		defer cancel()
+		if shutdownErr := httpServer.Shutdown(shutdownCtx); shutdownErr != nil {
+			slog.Error("Server shutdown error", "error", shutdownErr)
+			_ = httpServer.Close()
 		}
+		// Animations are stopped once no request can start a new one.
+		StopAllAnimations()
+	}()
+
+	if serveErr := httpServer.Serve(listener); serveErr != nil && serveErr != http.ErrServerClosed {
+		return fmt.Errorf("server error: %w", serveErr)
+	}
+	<-shutdownDone
+	return nil
+}
 
-		file, openErr := frontendSubFS.Open(path)
+// immutableAssetsPrefix is where SvelteKit puts build output with content
+// hashes in the file names, which can therefore be cached forever.
+const immutableAssetsPrefix = "_app/immutable/"
+
+// assetContentTypes are set explicitly rather than left to the system MIME
+// tables, which on some hosts map .js to text/plain and make browsers refuse
+// to run the app.
+var assetContentTypes = map[string]string{
+	".js":   "text/javascript; charset=utf-8",
+	".mjs":  "text/javascript; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".wasm": "application/wasm",
+	".json": "application/json",
+	".html": "text/html; charset=utf-8",
+}
+
+// spaHandler serves the frontend build. Paths without a file extension that
+// match no file are client-side routes and get index.html, so deep links
+// and reloads work; missing assets are still 404. Hashed assets are cached
+// for good, everything else is revalidated on every load.
+func spaHandler(fsys fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name == "" {
+			name = "index.html"
+		}
+
+		file, stat, openErr := openSPAFile(fsys, name)
+		if openErr != nil && path.Ext(name) == "" {
+			name = "index.html"
+			file, stat, openErr = openSPAFile(fsys, name)
+		}
 		if openErr != nil {
 			http.NotFound(w, r)
 			return
 		}
 		defer file.Close()
 
-		stat, statErr := file.Stat()
-		if statErr != nil {
-			http.Error(w, "Error reading file", http.StatusInternalServerError)
-			return
-		}
-
 		rs, ok := file.(io.ReadSeeker)
 		if !ok {
 			http.Error(w, "Error reading file", http.StatusInternalServerError)
 			return
 		}
-		http.ServeContent(w, r, path, stat.ModTime(), rs)
-	})
-	mux.Handle("/", spaHandler)
 
-	httpServer := &http.Server{
-		Addr:    ":" + port,
-		Handler: mux,
-	}
-
-	slog.Info("Starting Cubik server", "address", "http://localhost:"+port)
-
-	go func() {
-		<-ctx.Done()
-		slog.Info("Shutting down server...")
-		if shutdownErr := httpServer.Shutdown(context.Background()); shutdownErr != nil {
-			slog.Error("Server shutdown error", "error", shutdownErr)
+		if strings.HasPrefix(name, immutableAssetsPrefix) {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-cache")
 		}
-	}()
+		if contentType, known := assetContentTypes[path.Ext(name)]; known {
+			w.Header().Set("Content-Type", contentType)
+		}
+		http.ServeContent(w, r, name, stat.ModTime(), rs)
+	})
+}
 
-	if listenErr := httpServer.ListenAndServe(); listenErr != nil && listenErr != http.ErrServerClosed {
-		return fmt.Errorf("server error: %w", listenErr)
+// openSPAFile opens the regular file name in fsys.
+func openSPAFile(fsys fs.FS, name string) (fs.File, fs.FileInfo, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if stat.IsDir() {
+		file.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+	return file, stat, nil
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"cubik/api"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ogen-go/ogen/middleware"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		reply      func(cmd CommandRequest) []string
+		wantStatus int
+	}{
+		{name: "device answers in time", reply: replyOK, wantStatus: http.StatusOK},
+		{name: "device hangs", reply: replyNothing, wantStatus: http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := newFakeDevice(t, tt.reply)
+			srv, err := api.NewServer(
+				&APIHandler{},
+				api.WithMiddleware(timeoutMiddleware(100*time.Millisecond)),
+				api.WithErrorHandler(apiErrorHandler),
+			)
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+
+			body := `{"device_location":"` + device.Location() + `","power":true}`
+			req := httptest.NewRequest(http.MethodPost, "/api/device/power", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareResults(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+	errDevice := errors.New("device answered with an error")
+
+	tests := []struct {
+		name        string
+		next        func(ctx context.Context) (middleware.Response, error)
+		wantType    any
+		wantErr     error
+		wantTimeout bool
+	}{
+		{
+			name: "fast success",
+			next: func(context.Context) (middleware.Response, error) {
+				return middleware.Response{Type: "ok"}, nil
+			},
+			wantType: "ok",
+		},
+		{
+			name: "slow success is kept",
+			next: func(context.Context) (middleware.Response, error) {
+				time.Sleep(2 * timeout)
+				return middleware.Response{Type: "ok"}, nil
+			},
+			wantType: "ok",
+		},
+		{
+			name: "slow failure unrelated to the deadline",
+			next: func(context.Context) (middleware.Response, error) {
+				time.Sleep(2 * timeout)
+				return middleware.Response{}, errDevice
+			},
+			wantErr: errDevice,
+		},
+		{
+			name: "failure caused by the deadline",
+			next: func(ctx context.Context) (middleware.Response, error) {
+				<-ctx.Done()
+				return middleware.Response{}, ctx.Err()
+			},
+			wantErr:     context.DeadlineExceeded,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := middleware.Request{Context: context.Background(), OperationID: "test"}
+			resp, err := timeoutMiddleware(timeout)(req, func(req middleware.Request) (middleware.Response, error) {
+				return tt.next(req.Context)
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("timeoutMiddleware() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantTimeout && !strings.Contains(err.Error(), "timed out after") {
+				t.Errorf("timeoutMiddleware() error = %v, want it reported as a timeout", err)
+			}
+			if resp.Type != tt.wantType {
+				t.Errorf("timeoutMiddleware() response = %v, want %v", resp.Type, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"cubik/api"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// writeSSEEvent writes a single Server-Sent Event and flushes it to the client.
+func writeSSEEvent(w http.ResponseWriter, flusher http.Flusher, event string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	flusher.Flush()
+	return nil
+}
+
+// startSSE prepares w for an event stream, or reports an error to the client
+// and returns false if the connection does not support streaming.
+func startSSE(w http.ResponseWriter) (http.Flusher, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return flusher, true
+}
+
+// discoverStreamHandler serves GET /api/devices/discover/stream. It sends a
+// "device" event with an api.Device payload for every device as it is found
+// and a final "done" event when discovery finishes. With ?watch=true it
+// keeps searching and reports devices as they power on, never sending
+// "done". A client disconnect cancels the request context, which stops the
+// underlying discovery.
+func discoverStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("watch") == "true" {
+		watchStream(w, r)
+		return
+	}
+
+	devices, err := DiscoverDevicesStream(r.Context())
+	if err != nil {
+		slog.Debug("Discovery error", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	for device := range devices {
+		if writeErr := writeDeviceEvent(w, flusher, device); writeErr != nil {
+			return
+		}
+	}
+
+	_ = writeSSEEvent(w, flusher, "done", []byte("{}"))
+}
+
+// animationStatusStreamHandler serves
+// GET /api/animation/status/stream?device_location=yeelight://IP:PORT. It
+// sends a "status" event with the current AnimationStatus right away and
+// another one for every frame played and every start or stop, until the
+// client disconnects.
+func animationStatusStreamHandler(w http.ResponseWriter, r *http.Request) {
+	deviceLocation := r.URL.Query().Get("device_location")
+	if _, err := parseLocation(deviceLocation); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updates, unsubscribe := animationStatuses.Subscribe(deviceLocation)
+	defer unsubscribe()
+
+	flusher, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	status := CurrentAnimationStatus(deviceLocation)
+	for {
+		data, marshalErr := json.Marshal(status)
+		if marshalErr != nil {
+			slog.Error("Failed to encode animation status", "error", marshalErr)
+			return
+		}
+		if writeErr := writeSSEEvent(w, flusher, "status", data); writeErr != nil {
+			return
+		}
+
+		select {
+		case status = <-updates:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func watchStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := startSSE(w)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	err := WatchDevices(ctx, func(device *DeviceInfo) {
+		if writeErr := writeDeviceEvent(w, flusher, device); writeErr != nil {
+			cancel()
+		}
+	})
+	if err != nil {
+		slog.Debug("Discovery error", "error", err)
+	}
+}
+
+// writeDeviceEvent sends device as a "device" event with an api.Device
+// payload.
+func writeDeviceEvent(w http.ResponseWriter, flusher http.Flusher, device *DeviceInfo) error {
+	payload := api.Device{
+		ID:         device.ID,
+		Name:       device.Name,
+		Location:   device.Location,
+		IDConflict: device.IDConflict,
+	}
+	data, marshalErr := payload.MarshalJSON()
+	if marshalErr != nil {
+		slog.Error("Failed to encode device", "error", marshalErr)
+		return nil
+	}
+	return writeSSEEvent(w, flusher, "device", data)
+}
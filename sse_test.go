@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWriteDeviceEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		device *DeviceInfo
+		want   string
+	}{
+		{
+			name:   "device",
+			device: &DeviceInfo{ID: "0x1", Name: "desk", Location: "yeelight://192.168.1.10:55443"},
+			want: "event: device\n" +
+				`data: {"id":"0x1","name":"desk","location":"yeelight://192.168.1.10:55443","id_conflict":false}` +
+				"\n\n",
+		},
+		{
+			name:   "conflicting ID",
+			device: &DeviceInfo{ID: "0x1", Location: "yeelight://192.168.1.11:55443", IDConflict: true},
+			want: "event: device\n" +
+				`data: {"id":"0x1","name":"","location":"yeelight://192.168.1.11:55443","id_conflict":true}` +
+				"\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := writeDeviceEvent(rec, rec, tt.device); err != nil {
+				t.Fatalf("writeDeviceEvent() error = %v", err)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("writeDeviceEvent() wrote %q, want %q", got, tt.want)
+			}
+			if !rec.Flushed {
+				t.Error("writeDeviceEvent() did not flush the event")
+			}
+		})
+	}
+}
+
+func TestUntilShutdownEndsStreams(t *testing.T) {
+	shuttingDown, stopStreams := context.WithCancel(context.Background())
+	// stream stands in for a discovery watch, which runs until its request
+	// context is done.
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := startSSE(w)
+		if !ok {
+			return
+		}
+		_ = writeSSEEvent(w, flusher, "device", []byte("{}"))
+		<-r.Context().Done()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := httptest.NewRequest(http.MethodGet, "/api/devices/discover/stream?watch=true", nil)
+		untilShutdown(shuttingDown, stream).ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("stream ended before shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stopStreams()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream still open after shutdown started")
+	}
+}
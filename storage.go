@@ -6,18 +6,42 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var ErrNotFound = errors.New("animation not found")
+var (
+	ErrNotFound   = errors.New("animation not found")
+	ErrNoRevision = errors.New("no revision to restore")
+	// ErrFrameDurationsMismatch is returned by UpdateAnimation when the frame
+	// count changes but the per-frame durations are kept.
+	ErrFrameDurationsMismatch = errors.New("frame durations do not match the number of frames")
+)
+
+// maxAnimationRevisions bounds how many undo (and redo) steps are kept per
+// animation; older revisions are pruned.
+const maxAnimationRevisions = 20
+
+const (
+	revisionStackUndo = "undo"
+	revisionStackRedo = "redo"
+)
 
 type SavedAnimation struct {
-	ID        string
-	DeviceID  string
-	Name      string
-	Frames    [][]Color
+	ID       string
+	DeviceID string
+	Name     string
+	Frames   [][]Color
+	// FrameDuration is how long each frame is shown during playback.
+	FrameDuration time.Duration
+	// FrameDurations optionally overrides FrameDuration per frame. When set
+	// it has one entry per frame.
+	FrameDurations []time.Duration
+	// Tags are free-form labels for organizing animations, such as "holiday".
+	Tags      []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -28,14 +52,16 @@ type FrameJSON struct {
 	B uint8 `json:"b"`
 }
 
+// serializeFrames encodes frames as a JSON array of frames, each an array of
+// pixels.
 func serializeFrames(frames [][]Color) (string, error) {
-	jsonFrames := make([][][]FrameJSON, len(frames))
+	jsonFrames := make([][]FrameJSON, len(frames))
 	for i, frame := range frames {
 		jsonFrame := make([]FrameJSON, len(frame))
 		for j, color := range frame {
 			jsonFrame[j] = FrameJSON(color)
 		}
-		jsonFrames[i] = [][]FrameJSON{jsonFrame}
+		jsonFrames[i] = jsonFrame
 	}
 
 	data, err := json.Marshal(jsonFrames)
@@ -46,64 +72,178 @@ func serializeFrames(frames [][]Color) (string, error) {
 }
 
 func deserializeFrames(jsonStr string) ([][]Color, error) {
-	var jsonFrames [][][]FrameJSON
+	var jsonFrames [][]FrameJSON
 	if err := json.Unmarshal([]byte(jsonStr), &jsonFrames); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal frames: %w", err)
 	}
 
 	frames := make([][]Color, len(jsonFrames))
-	for i, jsonFrameWrapper := range jsonFrames {
-		if len(jsonFrameWrapper) > 0 {
-			jsonFrame := jsonFrameWrapper[0]
-			frame := make([]Color, len(jsonFrame))
-			for j, pixel := range jsonFrame {
-				frame[j] = Color(pixel)
-			}
-			frames[i] = frame
+	for i, jsonFrame := range jsonFrames {
+		frame := make([]Color, len(jsonFrame))
+		for j, pixel := range jsonFrame {
+			frame[j] = Color(pixel)
 		}
+		frames[i] = frame
 	}
 	return frames, nil
 }
 
-func SaveAnimation(ctx context.Context, db *sql.DB, deviceID, name string, frames [][]Color) (*SavedAnimation, error) {
+// serializeFrameDurations encodes per-frame durations as a JSON array of
+// milliseconds, or an empty string when there are none.
+func serializeFrameDurations(durations []time.Duration) (string, error) {
+	if len(durations) == 0 {
+		return "", nil
+	}
+
+	ms := make([]int64, len(durations))
+	for i, duration := range durations {
+		ms[i] = duration.Milliseconds()
+	}
+	data, err := json.Marshal(ms)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal frame durations: %w", err)
+	}
+	return string(data), nil
+}
+
+func deserializeFrameDurations(jsonStr string) ([]time.Duration, error) {
+	if jsonStr == "" {
+		return nil, nil
+	}
+
+	var ms []int64
+	if err := json.Unmarshal([]byte(jsonStr), &ms); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal frame durations: %w", err)
+	}
+	durations := make([]time.Duration, len(ms))
+	for i, value := range ms {
+		durations[i] = time.Duration(value) * time.Millisecond
+	}
+	return durations, nil
+}
+
+// normalizeTags trims the tags, lowercases them and drops empty and duplicate
+// ones, so filtering by tag does not depend on how a tag was typed.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+func serializeTags(tags []string) (string, error) {
+	data, err := json.Marshal(normalizeTags(tags))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	return string(data), nil
+}
+
+func deserializeTags(jsonStr string) ([]string, error) {
+	tags := []string{}
+	if err := json.Unmarshal([]byte(jsonStr), &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+	return tags, nil
+}
+
+func SaveAnimation(
+	ctx context.Context,
+	db *sql.DB,
+	deviceID, name string,
+	frames [][]Color,
+	frameDuration time.Duration,
+	frameDurations []time.Duration,
+	tags []string,
+) (*SavedAnimation, error) {
 	id := uuid.New().String()
 	framesJSON, err := serializeFrames(frames)
 	if err != nil {
 		return nil, err
 	}
+	durationsJSON, err := serializeFrameDurations(frameDurations)
+	if err != nil {
+		return nil, err
+	}
+	tags = normalizeTags(tags)
+	tagsJSON, err := serializeTags(tags)
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now().UTC()
 	timestamp := now.Format(time.RFC3339)
 
 	_, execErr := db.ExecContext(
 		ctx,
-		`INSERT INTO saved_animations (id, device_id, name, frames_json, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?)`,
-		id, deviceID, name, framesJSON, timestamp, timestamp,
+		`INSERT INTO saved_animations
+		   (id, device_id, name, frames_json, frame_duration_ms, frame_durations_json, tags_json,
+		    created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, deviceID, name, framesJSON, frameDuration.Milliseconds(), durationsJSON, tagsJSON, timestamp, timestamp,
 	)
 	if execErr != nil {
 		return nil, fmt.Errorf("failed to insert animation: %w", execErr)
 	}
 
 	return &SavedAnimation{
-		ID:        id,
-		DeviceID:  deviceID,
-		Name:      name,
-		Frames:    frames,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:             id,
+		DeviceID:       deviceID,
+		Name:           name,
+		Frames:         frames,
+		FrameDuration:  frameDuration,
+		FrameDurations: frameDurations,
+		Tags:           tags,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}, nil
 }
 
+// DuplicateAnimation saves a copy of the animation under newName, or under
+// the original name with a " copy" suffix when newName is empty. The copy
+// starts without undo history.
+func DuplicateAnimation(ctx context.Context, db *sql.DB, id, newName string) (*SavedAnimation, error) {
+	source, err := GetAnimation(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if newName == "" {
+		newName = source.Name + " copy"
+	}
+	return SaveAnimation(
+		ctx,
+		db,
+		source.DeviceID,
+		newName,
+		source.Frames,
+		source.FrameDuration,
+		source.FrameDurations,
+		source.Tags,
+	)
+}
+
+// GetAnimation returns the animation with the given ID, served from
+// animationCache when possible. The result must not be modified.
 func GetAnimation(ctx context.Context, db *sql.DB, id string) (*SavedAnimation, error) {
-	var deviceID, name, framesJSON, createdAt, updatedAt string
+	cache := animationCache
+	cached, generation, ok := cache.Get(id)
+	if ok {
+		return cached, nil
+	}
+
+	var deviceID, name, framesJSON, durationsJSON, tagsJSON, createdAt, updatedAt string
+	var frameDurationMs int64
 
 	queryErr := db.QueryRowContext(
 		ctx,
-		`SELECT device_id, name, frames_json, created_at, updated_at
+		`SELECT device_id, name, frames_json, frame_duration_ms, frame_durations_json, tags_json, created_at, updated_at
 		 FROM saved_animations WHERE id = ?`,
 		id,
-	).Scan(&deviceID, &name, &framesJSON, &createdAt, &updatedAt)
+	).Scan(&deviceID, &name, &framesJSON, &frameDurationMs, &durationsJSON, &tagsJSON, &createdAt, &updatedAt)
 
 	if errors.Is(queryErr, sql.ErrNoRows) {
 		return nil, ErrNotFound
@@ -116,27 +256,122 @@ func GetAnimation(ctx context.Context, db *sql.DB, id string) (*SavedAnimation,
 	if deserializeErr != nil {
 		return nil, deserializeErr
 	}
+	frameDurations, deserializeErr := deserializeFrameDurations(durationsJSON)
+	if deserializeErr != nil {
+		return nil, deserializeErr
+	}
+	tags, deserializeErr := deserializeTags(tagsJSON)
+	if deserializeErr != nil {
+		return nil, deserializeErr
+	}
 
 	createdTime, _ := time.Parse(time.RFC3339, createdAt)
 	updatedTime, _ := time.Parse(time.RFC3339, updatedAt)
 
-	return &SavedAnimation{
-		ID:        id,
-		DeviceID:  deviceID,
-		Name:      name,
-		Frames:    frames,
-		CreatedAt: createdTime,
-		UpdatedAt: updatedTime,
-	}, nil
+	animation := &SavedAnimation{
+		ID:             id,
+		DeviceID:       deviceID,
+		Name:           name,
+		Frames:         frames,
+		FrameDuration:  time.Duration(frameDurationMs) * time.Millisecond,
+		FrameDurations: frameDurations,
+		Tags:           tags,
+		CreatedAt:      createdTime,
+		UpdatedAt:      updatedTime,
+	}
+	cache.Put(animation, generation)
+	return animation, nil
 }
 
-func ListAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID string) ([]*SavedAnimation, error) {
-	rows, queryErr := db.QueryContext(
-		ctx,
-		`SELECT id, name, frames_json, created_at, updated_at
-		 FROM saved_animations WHERE device_id = ? ORDER BY updated_at DESC`,
-		deviceID,
-	)
+// ListAnimationsByDevice returns the device's animations, most recently
+// updated first. When tag is not empty only animations with that tag are
+// returned.
+func ListAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID, tag string) ([]*SavedAnimation, error) {
+	return FilterAnimations(ctx, db, deviceID, AnimationFilter{Tag: tag})
+}
+
+// SearchAnimations returns the device's animations whose name contains query,
+// ignoring ASCII case, ordered like ListAnimationsByDevice.
+func SearchAnimations(ctx context.Context, db *sql.DB, deviceID, query string) ([]*SavedAnimation, error) {
+	return FilterAnimations(ctx, db, deviceID, AnimationFilter{Query: query})
+}
+
+// AnimationFilter narrows down and orders the animations returned by
+// FilterAnimations. Empty fields do not filter.
+type AnimationFilter struct {
+	// Query matches animations whose name contains it, ignoring ASCII case.
+	Query string
+	// Tag matches animations carrying the tag.
+	Tag string
+	// CreatedAfter and CreatedBefore bound the creation time, inclusively.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// SortBy is one of the animationSortColumns keys. Empty sorts by
+	// "updated_at".
+	SortBy string
+	// Ascending sorts in ascending instead of descending order.
+	Ascending bool
+}
+
+// ErrInvalidSort is returned for a sort field that is not allowed.
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// animationSortColumns maps the allowed sort fields to the SQL they sort by.
+// Only these are ever interpolated into a query.
+var animationSortColumns = map[string]string{
+	"name":       "name COLLATE NOCASE",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// FilterAnimations returns the device's animations matching filter, by
+// default most recently updated first.
+func FilterAnimations(
+	ctx context.Context,
+	db *sql.DB,
+	deviceID string,
+	filter AnimationFilter,
+) ([]*SavedAnimation, error) {
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = "updated_at"
+	}
+	sortColumn, ok := animationSortColumns[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSort, filter.SortBy)
+	}
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+
+	query := `SELECT id, name, frames_json, frame_duration_ms, frame_durations_json, tags_json, created_at, updated_at
+		 FROM saved_animations WHERE device_id = ?`
+	args := []any{deviceID}
+	if filter.Query != "" {
+		query += ` AND name LIKE ? ESCAPE '\'`
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+	}
+	if tag := strings.ToLower(strings.TrimSpace(filter.Tag)); tag != "" {
+		query += ` AND EXISTS (SELECT 1 FROM json_each(tags_json) WHERE value = ?)`
+		args = append(args, tag)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query += ` AND created_at >= ?`
+		args = append(args, filter.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query += ` AND created_at <= ?`
+		args = append(args, filter.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+	query += ` ORDER BY ` + sortColumn + ` ` + direction + `, id`
+
+	rows, queryErr := db.QueryContext(ctx, query, args...)
 	if queryErr != nil {
 		return nil, fmt.Errorf("failed to query animations: %w", queryErr)
 	}
@@ -144,8 +379,12 @@ func ListAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID string) ([
 
 	var animations []*SavedAnimation
 	for rows.Next() {
-		var id, name, framesJSON, createdAt, updatedAt string
-		if scanErr := rows.Scan(&id, &name, &framesJSON, &createdAt, &updatedAt); scanErr != nil {
+		var id, name, framesJSON, durationsJSON, tagsJSON, createdAt, updatedAt string
+		var frameDurationMs int64
+		scanErr := rows.Scan(
+			&id, &name, &framesJSON, &frameDurationMs, &durationsJSON, &tagsJSON, &createdAt, &updatedAt,
+		)
+		if scanErr != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", scanErr)
 		}
 
@@ -153,17 +392,28 @@ func ListAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID string) ([
 		if deserializeErr != nil {
 			return nil, deserializeErr
 		}
+		frameDurations, deserializeErr := deserializeFrameDurations(durationsJSON)
+		if deserializeErr != nil {
+			return nil, deserializeErr
+		}
+		tags, deserializeErr := deserializeTags(tagsJSON)
+		if deserializeErr != nil {
+			return nil, deserializeErr
+		}
 
 		createdTime, _ := time.Parse(time.RFC3339, createdAt)
 		updatedTime, _ := time.Parse(time.RFC3339, updatedAt)
 
 		animations = append(animations, &SavedAnimation{
-			ID:        id,
-			DeviceID:  deviceID,
-			Name:      name,
-			Frames:    frames,
-			CreatedAt: createdTime,
-			UpdatedAt: updatedTime,
+			ID:             id,
+			DeviceID:       deviceID,
+			Name:           name,
+			Frames:         frames,
+			FrameDuration:  time.Duration(frameDurationMs) * time.Millisecond,
+			FrameDurations: frameDurations,
+			Tags:           tags,
+			CreatedAt:      createdTime,
+			UpdatedAt:      updatedTime,
 		})
 	}
 
@@ -173,35 +423,257 @@ func ListAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID string) ([
 	return animations, nil
 }
 
-func UpdateAnimation(ctx context.Context, db *sql.DB, id, name string, frames [][]Color) (*SavedAnimation, error) {
+// UpdateAnimation overwrites the animation and records its previous state as
+// an undo revision. Any redo revisions are discarded. A nil tags slice keeps
+// the current tags.
+func UpdateAnimation(
+	ctx context.Context,
+	db *sql.DB,
+	id, name string,
+	frames [][]Color,
+	frameDuration time.Duration,
+	frameDurations []time.Duration,
+	tags []string,
+) (*SavedAnimation, error) {
 	framesJSON, err := serializeFrames(frames)
 	if err != nil {
 		return nil, err
 	}
+	var frameDurationMs *int64
+	if frameDuration > 0 {
+		ms := frameDuration.Milliseconds()
+		frameDurationMs = &ms
+	}
+	var durationsJSON *string
+	if frameDurations != nil {
+		if len(frameDurations) > 0 && len(frameDurations) != len(frames) {
+			return nil, ErrFrameDurationsMismatch
+		}
+		encoded, serializeErr := serializeFrameDurations(frameDurations)
+		if serializeErr != nil {
+			return nil, serializeErr
+		}
+		durationsJSON = &encoded
+	}
+	var tagsJSON *string
+	if tags != nil {
+		encoded, serializeErr := serializeTags(tags)
+		if serializeErr != nil {
+			return nil, serializeErr
+		}
+		tagsJSON = &encoded
+	}
+
+	tx, txErr := db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", txErr)
+	}
+	defer tx.Rollback()
+
+	if pushErr := pushRevision(ctx, tx, id, revisionStackUndo); pushErr != nil {
+		return nil, pushErr
+	}
+
+	if _, execErr := tx.ExecContext(
+		ctx,
+		`DELETE FROM animation_revisions WHERE animation_id = ? AND stack = ?`,
+		id, revisionStackRedo,
+	); execErr != nil {
+		return nil, fmt.Errorf("failed to discard redo revisions: %w", execErr)
+	}
+
+	if durationsJSON == nil {
+		_, matches, durationsErr := storedFrameDurations(ctx, tx, id, len(frames))
+		if durationsErr != nil {
+			return nil, durationsErr
+		}
+		if !matches {
+			return nil, ErrFrameDurationsMismatch
+		}
+	}
 
 	updatedAt := time.Now().UTC().Format(time.RFC3339)
-	result, execErr := db.ExecContext(
+	if _, execErr := tx.ExecContext(
+		ctx,
+		`UPDATE saved_animations
+		 SET name = ?, frames_json = ?,
+		     frame_duration_ms = COALESCE(?, frame_duration_ms),
+		     frame_durations_json = COALESCE(?, frame_durations_json),
+		     tags_json = COALESCE(?, tags_json), updated_at = ?
+		 WHERE id = ?`,
+		name, framesJSON, frameDurationMs, durationsJSON, tagsJSON, updatedAt, id,
+	); execErr != nil {
+		return nil, fmt.Errorf("failed to update animation: %w", execErr)
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	animationCache.Remove(id)
+
+	return GetAnimation(ctx, db, id)
+}
+
+// storedFrameDurations returns the stored per-frame durations of the
+// animation and whether they are empty or cover exactly frameCount frames.
+func storedFrameDurations(ctx context.Context, tx *sql.Tx, id string, frameCount int) (string, bool, error) {
+	var durationsJSON string
+	queryErr := tx.QueryRowContext(
 		ctx,
-		`UPDATE saved_animations SET name = ?, frames_json = ?, updated_at = ? WHERE id = ?`,
-		name, framesJSON, updatedAt, id,
+		`SELECT frame_durations_json FROM saved_animations WHERE id = ?`,
+		id,
+	).Scan(&durationsJSON)
+	if queryErr != nil {
+		return "", false, fmt.Errorf("failed to query frame durations: %w", queryErr)
+	}
+
+	durations, err := deserializeFrameDurations(durationsJSON)
+	if err != nil {
+		return "", false, err
+	}
+	return durationsJSON, len(durations) == 0 || len(durations) == frameCount, nil
+}
+
+// UndoAnimation restores the animation to the state before its last update.
+// The current state becomes a redo revision.
+func UndoAnimation(ctx context.Context, db *sql.DB, id string) (*SavedAnimation, error) {
+	return restoreRevision(ctx, db, id, revisionStackUndo, revisionStackRedo)
+}
+
+// RedoAnimation reapplies the last update reverted by UndoAnimation.
+func RedoAnimation(ctx context.Context, db *sql.DB, id string) (*SavedAnimation, error) {
+	return restoreRevision(ctx, db, id, revisionStackRedo, revisionStackUndo)
+}
+
+// restoreRevision pops the newest revision from the from stack, saves the
+// current state onto the to stack and makes the popped revision current.
+func restoreRevision(ctx context.Context, db *sql.DB, id, from, to string) (*SavedAnimation, error) {
+	tx, txErr := db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", txErr)
+	}
+	defer tx.Rollback()
+
+	var revisionID int64
+	var name, framesJSON string
+	var frameDurationMs sql.NullInt64
+	var durationsJSON sql.NullString
+	queryErr := tx.QueryRowContext(
+		ctx,
+		`SELECT id, name, frames_json, frame_duration_ms, frame_durations_json FROM animation_revisions
+		 WHERE animation_id = ? AND stack = ? ORDER BY id DESC LIMIT 1`,
+		id, from,
+	).Scan(&revisionID, &name, &framesJSON, &frameDurationMs, &durationsJSON)
+	if errors.Is(queryErr, sql.ErrNoRows) {
+		var exists int
+		existsErr := tx.QueryRowContext(ctx, `SELECT 1 FROM saved_animations WHERE id = ?`, id).Scan(&exists)
+		if errors.Is(existsErr, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if existsErr != nil {
+			return nil, fmt.Errorf("failed to query animation: %w", existsErr)
+		}
+		return nil, ErrNoRevision
+	}
+	if queryErr != nil {
+		return nil, fmt.Errorf("failed to query revision: %w", queryErr)
+	}
+
+	if pushErr := pushRevision(ctx, tx, id, to); pushErr != nil {
+		return nil, pushErr
+	}
+
+	if _, execErr := tx.ExecContext(ctx, `DELETE FROM animation_revisions WHERE id = ?`, revisionID); execErr != nil {
+		return nil, fmt.Errorf("failed to delete revision: %w", execErr)
+	}
+
+	// Revisions recorded before they carried timing keep the current timing,
+	// minus per-frame durations that no longer fit the restored frames.
+	if !durationsJSON.Valid {
+		frames, deserializeErr := deserializeFrames(framesJSON)
+		if deserializeErr != nil {
+			return nil, deserializeErr
+		}
+		current, matches, durationsErr := storedFrameDurations(ctx, tx, id, len(frames))
+		if durationsErr != nil {
+			return nil, durationsErr
+		}
+		if !matches {
+			current = ""
+		}
+		durationsJSON = sql.NullString{String: current, Valid: true}
+	}
+
+	updatedAt := time.Now().UTC().Format(time.RFC3339)
+	if _, execErr := tx.ExecContext(
+		ctx,
+		`UPDATE saved_animations
+		 SET name = ?, frames_json = ?,
+		     frame_duration_ms = COALESCE(?, frame_duration_ms), frame_durations_json = ?, updated_at = ?
+		 WHERE id = ?`,
+		name, framesJSON, frameDurationMs, durationsJSON, updatedAt, id,
+	); execErr != nil {
+		return nil, fmt.Errorf("failed to restore animation: %w", execErr)
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	animationCache.Remove(id)
+
+	return GetAnimation(ctx, db, id)
+}
+
+// pushRevision copies the current state of the animation onto the given
+// revision stack and prunes the stack to maxAnimationRevisions entries.
+func pushRevision(ctx context.Context, tx *sql.Tx, id, stack string) error {
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	result, execErr := tx.ExecContext(
+		ctx,
+		`INSERT INTO animation_revisions
+		   (animation_id, stack, name, frames_json, frame_duration_ms, frame_durations_json, created_at)
+		 SELECT id, ?, name, frames_json, frame_duration_ms, frame_durations_json, ?
+		 FROM saved_animations WHERE id = ?`,
+		stack, createdAt, id,
 	)
 	if execErr != nil {
-		return nil, fmt.Errorf("failed to update animation: %w", execErr)
+		return fmt.Errorf("failed to record revision: %w", execErr)
 	}
 
 	rowsAffected, rowsErr := result.RowsAffected()
 	if rowsErr != nil {
-		return nil, fmt.Errorf("failed to get rows affected: %w", rowsErr)
+		return fmt.Errorf("failed to get rows affected: %w", rowsErr)
 	}
 	if rowsAffected == 0 {
-		return nil, ErrNotFound
+		return ErrNotFound
 	}
 
-	return GetAnimation(ctx, db, id)
+	if _, pruneErr := tx.ExecContext(
+		ctx,
+		`DELETE FROM animation_revisions
+		 WHERE animation_id = ? AND stack = ? AND id NOT IN (
+		   SELECT id FROM animation_revisions
+		   WHERE animation_id = ? AND stack = ? ORDER BY id DESC LIMIT ?
+		 )`,
+		id, stack, id, stack, maxAnimationRevisions,
+	); pruneErr != nil {
+		return fmt.Errorf("failed to prune revisions: %w", pruneErr)
+	}
+	return nil
 }
 
 func DeleteAnimation(ctx context.Context, db *sql.DB, id string) error {
-	result, execErr := db.ExecContext(ctx, `DELETE FROM saved_animations WHERE id = ?`, id)
+	tx, txErr := db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return fmt.Errorf("failed to begin transaction: %w", txErr)
+	}
+	defer tx.Rollback()
+
+	if _, execErr := tx.ExecContext(ctx, `DELETE FROM animation_revisions WHERE animation_id = ?`, id); execErr != nil {
+		return fmt.Errorf("failed to delete revisions: %w", execErr)
+	}
+
+	result, execErr := tx.ExecContext(ctx, `DELETE FROM saved_animations WHERE id = ?`, id)
 	if execErr != nil {
 		return fmt.Errorf("failed to delete animation: %w", execErr)
 	}
@@ -213,5 +685,198 @@ func DeleteAnimation(ctx context.Context, db *sql.DB, id string) error {
 	if rowsAffected == 0 {
 		return ErrNotFound
 	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	animationCache.Remove(id)
+	return nil
+}
+
+// DeleteAnimationsByDevice deletes every animation saved for the device and
+// returns how many were deleted.
+func DeleteAnimationsByDevice(ctx context.Context, db *sql.DB, deviceID string) (int, error) {
+	return deleteAnimationsWhere(ctx, db, `device_id = ?`, deviceID)
+}
+
+// DeleteAnimationsByIDs deletes the animations with the given IDs and returns
+// how many were deleted. IDs that do not exist are ignored.
+func DeleteAnimationsByIDs(ctx context.Context, db *sql.DB, ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return deleteAnimationsWhere(ctx, db, `id IN (`+placeholders+`)`, args...)
+}
+
+// deleteAnimationsWhere deletes the animations matching condition together
+// with their revisions and returns how many animations were deleted.
+func deleteAnimationsWhere(ctx context.Context, db *sql.DB, condition string, args ...any) (int, error) {
+	tx, txErr := db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", txErr)
+	}
+	defer tx.Rollback()
+
+	if _, execErr := tx.ExecContext(
+		ctx,
+		`DELETE FROM animation_revisions WHERE animation_id IN (SELECT id FROM saved_animations WHERE `+condition+`)`,
+		args...,
+	); execErr != nil {
+		return 0, fmt.Errorf("failed to delete revisions: %w", execErr)
+	}
+
+	rows, queryErr := tx.QueryContext(ctx, `DELETE FROM saved_animations WHERE `+condition+` RETURNING id`, args...)
+	if queryErr != nil {
+		return 0, fmt.Errorf("failed to delete animations: %w", queryErr)
+	}
+	var deleted []string
+	for rows.Next() {
+		var id string
+		if scanErr := rows.Scan(&id); scanErr != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", scanErr)
+		}
+		deleted = append(deleted, id)
+	}
+	rows.Close()
+	if iterErr := rows.Err(); iterErr != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", iterErr)
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	for _, id := range deleted {
+		animationCache.Remove(id)
+	}
+	return len(deleted), nil
+}
+
+func SaveWhiteBalance(ctx context.Context, db *sql.DB, deviceLocation string, wb WhiteBalance) error {
+	updatedAt := time.Now().UTC().Format(time.RFC3339)
+	_, execErr := db.ExecContext(
+		ctx,
+		`INSERT INTO device_settings (device_location, white_balance_r, white_balance_g, white_balance_b, updated_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(device_location) DO UPDATE SET
+		   white_balance_r = excluded.white_balance_r,
+		   white_balance_g = excluded.white_balance_g,
+		   white_balance_b = excluded.white_balance_b,
+		   updated_at = excluded.updated_at`,
+		deviceLocation, wb.R, wb.G, wb.B, updatedAt,
+	)
+	if execErr != nil {
+		return fmt.Errorf("failed to save white balance: %w", execErr)
+	}
 	return nil
 }
+
+func ListWhiteBalances(ctx context.Context, db *sql.DB) (map[string]WhiteBalance, error) {
+	rows, queryErr := db.QueryContext(
+		ctx,
+		`SELECT device_location, white_balance_r, white_balance_g, white_balance_b FROM device_settings`,
+	)
+	if queryErr != nil {
+		return nil, fmt.Errorf("failed to query white balances: %w", queryErr)
+	}
+	defer rows.Close()
+
+	balances := make(map[string]WhiteBalance)
+	for rows.Next() {
+		var deviceLocation string
+		var wb WhiteBalance
+		if scanErr := rows.Scan(&deviceLocation, &wb.R, &wb.G, &wb.B); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", scanErr)
+		}
+		balances[deviceLocation] = wb
+	}
+
+	if iterErr := rows.Err(); iterErr != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", iterErr)
+	}
+	return balances, nil
+}
+
+// SavedDevice is a device remembered from an earlier discovery.
+type SavedDevice struct {
+	ID       string
+	Location string
+	Model    string
+	Name     string
+	LastSeen time.Time
+}
+
+// UpsertDevice records device as seen now, replacing any earlier record with
+// the same ID.
+func UpsertDevice(ctx context.Context, db *sql.DB, device *DeviceInfo) error {
+	lastSeen := time.Now().UTC().Format(time.RFC3339)
+	_, execErr := db.ExecContext(
+		ctx,
+		`INSERT INTO devices (id, location, model, name, last_seen)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   location = excluded.location,
+		   model = excluded.model,
+		   name = excluded.name,
+		   last_seen = excluded.last_seen`,
+		device.ID, device.Location, device.Model, device.Name, lastSeen,
+	)
+	if execErr != nil {
+		return fmt.Errorf("failed to save device: %w", execErr)
+	}
+	return nil
+}
+
+// ListDevices returns the remembered devices last seen at or after since,
+// most recently seen first.
+func ListDevices(ctx context.Context, db *sql.DB, since time.Time) ([]*SavedDevice, error) {
+	rows, queryErr := db.QueryContext(
+		ctx,
+		`SELECT id, location, model, name, last_seen
+		 FROM devices WHERE last_seen >= ? ORDER BY last_seen DESC`,
+		since.UTC().Format(time.RFC3339),
+	)
+	if queryErr != nil {
+		return nil, fmt.Errorf("failed to query devices: %w", queryErr)
+	}
+	defer rows.Close()
+
+	var devices []*SavedDevice
+	for rows.Next() {
+		var device SavedDevice
+		var lastSeen string
+		if scanErr := rows.Scan(&device.ID, &device.Location, &device.Model, &device.Name, &lastSeen); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", scanErr)
+		}
+		device.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+		devices = append(devices, &device)
+	}
+
+	if iterErr := rows.Err(); iterErr != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", iterErr)
+	}
+	return devices, nil
+}
+
+// DeviceModel returns the model last reported by the device at location, or
+// "" if no device was discovered there.
+func DeviceModel(ctx context.Context, db *sql.DB, location string) (string, error) {
+	var model string
+	queryErr := db.QueryRowContext(
+		ctx,
+		`SELECT model FROM devices WHERE location = ? ORDER BY last_seen DESC LIMIT 1`,
+		location,
+	).Scan(&model)
+	if errors.Is(queryErr, sql.ErrNoRows) {
+		return "", nil
+	}
+	if queryErr != nil {
+		return "", fmt.Errorf("failed to query device model: %w", queryErr)
+	}
+	return model, nil
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestDB returns a migrated database in a temporary directory.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := InitDB(context.Background(), filepath.Join(t.TempDir(), "cubik.db"))
+	if err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	return db
+}
+
+// revisionStep is one call in an undo/redo scenario: an update to name, an
+// undo or a redo, and the outcome expected from it.
+type revisionStep struct {
+	op       string
+	name     string
+	wantName string
+	wantErr  error
+}
+
+func TestUndoRedoAnimation(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []revisionStep
+	}{
+		{
+			name: "nothing to undo or redo",
+			steps: []revisionStep{
+				{op: "undo", wantErr: ErrNoRevision},
+				{op: "redo", wantErr: ErrNoRevision},
+			},
+		},
+		{
+			name: "undo and redo walk the history",
+			steps: []revisionStep{
+				{op: "update", name: "b", wantName: "b"},
+				{op: "update", name: "c", wantName: "c"},
+				{op: "undo", wantName: "b"},
+				{op: "undo", wantName: "a"},
+				{op: "undo", wantErr: ErrNoRevision},
+				{op: "redo", wantName: "b"},
+				{op: "redo", wantName: "c"},
+				{op: "redo", wantErr: ErrNoRevision},
+			},
+		},
+		{
+			name: "an update discards the redo history",
+			steps: []revisionStep{
+				{op: "update", name: "b", wantName: "b"},
+				{op: "undo", wantName: "a"},
+				{op: "update", name: "c", wantName: "c"},
+				{op: "redo", wantErr: ErrNoRevision},
+				{op: "undo", wantName: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t)
+			frames := [][]Color{solidFrame(Color{R: 255})}
+			saved, err := SaveAnimation(ctx, db, "device", "a", frames, time.Second, nil, nil)
+			if err != nil {
+				t.Fatalf("SaveAnimation() error = %v", err)
+			}
+
+			for i, step := range tt.steps {
+				var animation *SavedAnimation
+				switch step.op {
+				case "update":
+					animation, err = UpdateAnimation(ctx, db, saved.ID, step.name, frames, 0, nil, nil)
+				case "undo":
+					animation, err = UndoAnimation(ctx, db, saved.ID)
+				case "redo":
+					animation, err = RedoAnimation(ctx, db, saved.ID)
+				}
+				if !errors.Is(err, step.wantErr) {
+					t.Fatalf("step %d (%s): error = %v, want %v", i, step.op, err, step.wantErr)
+				}
+				if err == nil && animation.Name != step.wantName {
+					t.Errorf("step %d (%s): name = %q, want %q", i, step.op, animation.Name, step.wantName)
+				}
+			}
+		})
+	}
+}
+
+func TestUndoAnimationKeepsBoundedHistory(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	frames := [][]Color{solidFrame(Color{R: 255})}
+	saved, err := SaveAnimation(ctx, db, "device", "a", frames, time.Second, nil, nil)
+	if err != nil {
+		t.Fatalf("SaveAnimation() error = %v", err)
+	}
+
+	for range maxAnimationRevisions + 5 {
+		if _, err := UpdateAnimation(ctx, db, saved.ID, "edited", frames, 0, nil, nil); err != nil {
+			t.Fatalf("UpdateAnimation() error = %v", err)
+		}
+	}
+
+	undone := 0
+	for {
+		if _, err := UndoAnimation(ctx, db, saved.ID); errors.Is(err, ErrNoRevision) {
+			break
+		} else if err != nil {
+			t.Fatalf("UndoAnimation() error = %v", err)
+		}
+		undone++
+	}
+	if undone != maxAnimationRevisions {
+		t.Errorf("undid %d updates, want the last %d", undone, maxAnimationRevisions)
+	}
+
+	if _, err := UndoAnimation(ctx, db, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UndoAnimation() of a missing animation error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestSerializeFrames(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames [][]Color
+		// wantJSON is the stored form; when empty only the round trip is
+		// checked.
+		wantJSON string
+	}{
+		{name: "no frames", frames: [][]Color{}, wantJSON: `[]`},
+		{
+			name:     "frames of pixels",
+			frames:   [][]Color{{{R: 1, G: 2, B: 3}, {R: 255}}, {{B: 7}, {}}},
+			wantJSON: `[[{"r":1,"g":2,"b":3},{"r":255,"g":0,"b":0}],[{"r":0,"g":0,"b":7},{"r":0,"g":0,"b":0}]]`,
+		},
+		{
+			name:   "full matrix",
+			frames: [][]Color{solidFrame(Color{R: 10, G: 20, B: 30}), solidFrame(white)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := serializeFrames(tt.frames)
+			if err != nil {
+				t.Fatalf("serializeFrames() error = %v", err)
+			}
+			if tt.wantJSON != "" && data != tt.wantJSON {
+				t.Errorf("serializeFrames() = %s, want %s", data, tt.wantJSON)
+			}
+
+			frames, err := deserializeFrames(data)
+			if err != nil {
+				t.Fatalf("deserializeFrames() error = %v", err)
+			}
+			if !slices.EqualFunc(frames, tt.frames, slices.Equal) {
+				t.Errorf("deserializeFrames(serializeFrames()) = %v, want %v", frames, tt.frames)
+			}
+		})
+	}
+}
+
+func TestFlattenFramesMigration(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitDB(ctx, filepath.Join(t.TempDir(), "cubik.db"))
+	if err != nil {
+		t.Fatalf("InitDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	m, err := newMigrate(db)
+	if err != nil {
+		t.Fatalf("newMigrate() error = %v", err)
+	}
+	// Stop before migration 7, when frames were stored wrapped in an extra
+	// array.
+	if err := m.Migrate(6); err != nil {
+		t.Fatalf("Migrate(6) error = %v", err)
+	}
+
+	frames := [][]Color{{{R: 1, G: 2, B: 3}, {R: 255}}, {{B: 7}, {}}}
+	nested := `[[[{"r":1,"g":2,"b":3},{"r":255,"g":0,"b":0}]],[[{"r":0,"g":0,"b":7},{"r":0,"g":0,"b":0}]]]`
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO saved_animations (id, device_id, name, frames_json, created_at, updated_at)
+		 VALUES ('legacy', 'device', 'legacy', ?, ?, ?)`,
+		nested, timestamp, timestamp,
+	); err != nil {
+		t.Fatalf("failed to insert animation: %v", err)
+	}
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO animation_revisions (animation_id, stack, name, frames_json, created_at)
+		 VALUES ('legacy', 'undo', 'before', ?, ?)`,
+		nested, timestamp,
+	); err != nil {
+		t.Fatalf("failed to insert revision: %v", err)
+	}
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	want, err := serializeFrames(frames)
+	if err != nil {
+		t.Fatalf("serializeFrames() error = %v", err)
+	}
+	for _, table := range []string{"saved_animations", "animation_revisions"} {
+		var got string
+		if err := db.QueryRowContext(ctx, `SELECT frames_json FROM `+table).Scan(&got); err != nil {
+			t.Fatalf("failed to query %s: %v", table, err)
+		}
+		if got != want {
+			t.Errorf("%s frames_json = %s, want %s", table, got, want)
+		}
+	}
+
+	animation, err := GetAnimation(ctx, db, "legacy")
+	if err != nil {
+		t.Fatalf("GetAnimation() error = %v", err)
+	}
+	if !slices.EqualFunc(animation.Frames, frames, slices.Equal) {
+		t.Errorf("GetAnimation() frames = %v, want %v", animation.Frames, frames)
+	}
+	restored, err := UndoAnimation(ctx, db, "legacy")
+	if err != nil {
+		t.Fatalf("UndoAnimation() error = %v", err)
+	}
+	if !slices.EqualFunc(restored.Frames, frames, slices.Equal) {
+		t.Errorf("UndoAnimation() frames = %v, want %v", restored.Frames, frames)
+	}
+
+	// Migrating down wraps the frames again.
+	if err := m.Migrate(6); err != nil {
+		t.Fatalf("Migrate(6) error = %v", err)
+	}
+	var got string
+	if err := db.QueryRowContext(ctx, `SELECT frames_json FROM saved_animations`).Scan(&got); err != nil {
+		t.Fatalf("failed to query saved_animations: %v", err)
+	}
+	if got != nested {
+		t.Errorf("frames_json after migrating down = %s, want %s", got, nested)
+	}
+}
+
+func TestFilterAnimationsByTag(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	frames := [][]Color{solidFrame(Color{R: 255})}
+	saved := map[string][]string{
+		"lights":  {"Holiday", " ambient "},
+		"alert":   {"notification"},
+		"plain":   nil,
+		"snow":    {"holiday", "HOLIDAY"},
+		"percent": {"50%"},
+	}
+	for name, tags := range saved {
+		if _, err := SaveAnimation(ctx, db, "device", name, frames, time.Second, nil, tags); err != nil {
+			t.Fatalf("SaveAnimation(%q) error = %v", name, err)
+		}
+	}
+	// Animations of other devices never match.
+	_, err := SaveAnimation(ctx, db, "other", "elsewhere", frames, time.Second, nil, []string{"holiday"})
+	if err != nil {
+		t.Fatalf("SaveAnimation() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		tag  string
+		want []string
+	}{
+		{name: "no tag lists everything", tag: "", want: []string{"alert", "lights", "percent", "plain", "snow"}},
+		{name: "matches ignoring case", tag: "HOLIDAY", want: []string{"lights", "snow"}},
+		{name: "trims the tag", tag: " ambient", want: []string{"lights"}},
+		{name: "whole tags only", tag: "holi", want: nil},
+		{name: "wildcards are literal", tag: "5%", want: nil},
+		{name: "unknown tag", tag: "birthday", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := AnimationFilter{Tag: tt.tag, SortBy: "name", Ascending: true}
+			animations, err := FilterAnimations(ctx, db, "device", filter)
+			if err != nil {
+				t.Fatalf("FilterAnimations() error = %v", err)
+			}
+			var got []string
+			for _, animation := range animations {
+				got = append(got, animation.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FilterAnimations(tag %q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveAnimationNormalizesTags(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	frames := [][]Color{solidFrame(Color{R: 255})}
+
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "no tags", tags: nil, want: []string{}},
+		{name: "lowercased and trimmed", tags: []string{" Holiday ", "AMBIENT"}, want: []string{"holiday", "ambient"}},
+		{name: "duplicates and blanks dropped", tags: []string{"a", "A", " ", "b", "a"}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved, err := SaveAnimation(ctx, db, "device", tt.name, frames, time.Second, nil, tt.tags)
+			if err != nil {
+				t.Fatalf("SaveAnimation() error = %v", err)
+			}
+			animation, err := GetAnimation(ctx, db, saved.ID)
+			if err != nil {
+				t.Fatalf("GetAnimation() error = %v", err)
+			}
+			if !slices.Equal(animation.Tags, tt.want) {
+				t.Errorf("GetAnimation() tags = %q, want %q", animation.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateAnimationTiming(t *testing.T) {
+	ms := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, len(values))
+		for i, v := range values {
+			durations[i] = time.Duration(v) * time.Millisecond
+		}
+		return durations
+	}
+	red, blue := solidFrame(Color{R: 255}), solidFrame(Color{B: 255})
+	two := [][]Color{red, blue}
+	three := [][]Color{red, blue, red}
+
+	tests := []struct {
+		name           string
+		frames         [][]Color
+		frameDuration  time.Duration
+		frameDurations []time.Duration
+		wantDuration   time.Duration
+		wantDurations  []time.Duration
+		wantErr        error
+	}{
+		{
+			name:   "keeps timing for the same frame count",
+			frames: two, wantDuration: 500 * time.Millisecond, wantDurations: ms(100, 200),
+		},
+		{
+			name:   "changes the frame duration",
+			frames: two, frameDuration: 250 * time.Millisecond,
+			wantDuration: 250 * time.Millisecond, wantDurations: ms(100, 200),
+		},
+		{
+			name:   "replaces durations with the frames",
+			frames: three, frameDurations: ms(10, 20, 30),
+			wantDuration: 500 * time.Millisecond, wantDurations: ms(10, 20, 30),
+		},
+		{
+			name:   "an empty list drops durations",
+			frames: three, frameDurations: []time.Duration{},
+			wantDuration: 500 * time.Millisecond,
+		},
+		{name: "kept durations must match the frames", frames: three, wantErr: ErrFrameDurationsMismatch},
+		{
+			name:   "new durations must match the frames",
+			frames: three, frameDurations: ms(10, 20), wantErr: ErrFrameDurationsMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestDB(t)
+			saved, err := SaveAnimation(ctx, db, "device", "a", two, 500*time.Millisecond, ms(100, 200), nil)
+			if err != nil {
+				t.Fatalf("SaveAnimation() error = %v", err)
+			}
+
+			updated, err := UpdateAnimation(ctx, db, saved.ID, "a", tt.frames, tt.frameDuration, tt.frameDurations, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateAnimation() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if _, err := UndoAnimation(ctx, db, saved.ID); !errors.Is(err, ErrNoRevision) {
+					t.Errorf("UndoAnimation() after a rejected update error = %v, want %v", err, ErrNoRevision)
+				}
+				return
+			}
+			if updated.FrameDuration != tt.wantDuration || !slices.Equal(updated.FrameDurations, tt.wantDurations) {
+				t.Errorf("UpdateAnimation() timing = %s %v, want %s %v",
+					updated.FrameDuration, updated.FrameDurations, tt.wantDuration, tt.wantDurations)
+			}
+
+			restored, err := UndoAnimation(ctx, db, saved.ID)
+			if err != nil {
+				t.Fatalf("UndoAnimation() error = %v", err)
+			}
+			if restored.FrameDuration != 500*time.Millisecond || !slices.Equal(restored.FrameDurations, ms(100, 200)) {
+				t.Errorf("UndoAnimation() timing = %s %v, want the original",
+					restored.FrameDuration, restored.FrameDurations)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "time"
+
+// SunriseColors are the key colors of a sunrise: the ramp goes from Night
+// through Dawn to Day. For luminance to rise steadily each channel of Dawn
+// should be at least that of Night, and each channel of Day at least that
+// of Dawn.
+type SunriseColors struct {
+	Night Color
+	Dawn  Color
+	Day   Color
+}
+
+// DefaultSunriseColors ramp from off through deep red to warm white.
+var DefaultSunriseColors = SunriseColors{
+	Night: Color{R: 0, G: 0, B: 0},
+	Dawn:  Color{R: 160, G: 16, B: 0},
+	Day:   Color{R: 255, G: 196, B: 128},
+}
+
+// GenerateSunrise returns a gradual-wake animation of durationFrames frames
+// using DefaultSunriseColors.
+func GenerateSunrise(durationFrames int) [][]Color {
+	return GenerateSunriseColors(durationFrames, DefaultSunriseColors)
+}
+
+// GenerateSunriseColors returns durationFrames frames that fade the whole
+// matrix from colors.Night to colors.Dawn over the first half and from
+// colors.Dawn to colors.Day over the second half. The last frame is always
+// colors.Day.
+func GenerateSunriseColors(durationFrames int, colors SunriseColors) [][]Color {
+	frames := make([][]Color, durationFrames)
+	for i := range frames {
+		progress := 1.0
+		if durationFrames > 1 {
+			progress = float64(i) / float64(durationFrames-1)
+		}
+
+		var c Color
+		if progress < 0.5 {
+			c = lerpColor(colors.Night, colors.Dawn, progress*2)
+		} else {
+			c = lerpColor(colors.Dawn, colors.Day, (progress-0.5)*2)
+		}
+
+		fb := NewMatrixFramebuffer()
+		for p := range fb.Pixels {
+			fb.Pixels[p] = c
+		}
+		frames[i] = fb.Pixels
+	}
+	return frames
+}
+
+// StartDeviceSunrise plays a sunrise lasting duration on the device and
+// stops on the final, fully lit frame.
+func StartDeviceSunrise(deviceLocation string, duration time.Duration, colors SunriseColors) {
+	frameCount := max(int(duration/defaultFrameDuration), 1)
+	StartDeviceAnimation(&AnimationState{
+		DeviceLocation: deviceLocation,
+		Frames:         GenerateSunriseColors(frameCount, colors),
+		FrameDuration:  defaultFrameDuration,
+		LoopCount:      1,
+	})
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+// luminance is the Rec. 709 weighted brightness of c, without gamma
+// correction; enough to compare colors on one ramp.
+func luminance(c Color) float64 {
+	return 0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)
+}
+
+func TestGenerateSunriseColors(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames int
+		colors SunriseColors
+	}{
+		{name: "default colors", frames: 60, colors: DefaultSunriseColors},
+		{name: "single frame", frames: 1, colors: DefaultSunriseColors},
+		{name: "two frames", frames: 2, colors: DefaultSunriseColors},
+		{
+			name:   "custom colors",
+			frames: 17,
+			colors: SunriseColors{
+				Night: Color{B: 10},
+				Dawn:  Color{R: 40, G: 20, B: 30},
+				Day:   Color{R: 200, G: 200, B: 255},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames := GenerateSunriseColors(tt.frames, tt.colors)
+			if len(frames) != tt.frames {
+				t.Fatalf("GenerateSunriseColors() returned %d frames, want %d", len(frames), tt.frames)
+			}
+
+			previous := -1.0
+			for i, frame := range frames {
+				if len(frame) != matrixWidth*matrixHeight {
+					t.Fatalf("frame %d has %d pixels, want %d", i, len(frame), matrixWidth*matrixHeight)
+				}
+				for _, c := range frame {
+					if c != frame[0] {
+						t.Fatalf("frame %d is not a single color: %+v and %+v", i, frame[0], c)
+					}
+				}
+				lum := luminance(frame[0])
+				if lum < previous {
+					t.Errorf("frame %d luminance %.1f is below the previous frame's %.1f", i, lum, previous)
+				}
+				previous = lum
+			}
+
+			if tt.frames > 1 && frames[0][0] != tt.colors.Night {
+				t.Errorf("first frame = %+v, want %+v", frames[0][0], tt.colors.Night)
+			}
+			if last := frames[len(frames)-1][0]; last != tt.colors.Day {
+				t.Errorf("last frame = %+v, want %+v", last, tt.colors.Day)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Frame rate bounds applied by the adaptive throttle, set from configuration.
+var (
+	animationMinFPS = 0.2
+	animationMaxFPS = 10.0
+)
+
+// SetAnimationFPSBounds sets the slowest and fastest frame rates the adaptive
+// throttle may settle on. Both must be positive and finite, and minFPS may
+// not exceed maxFPS.
+func SetAnimationFPSBounds(minFPS, maxFPS float64) error {
+	for _, fps := range []float64{minFPS, maxFPS} {
+		if !(fps > 0) || math.IsInf(fps, 1) {
+			return fmt.Errorf("animation frame rate bounds must be positive and finite, got %g", fps)
+		}
+	}
+	if minFPS > maxFPS {
+		return fmt.Errorf("minimum animation frame rate %g exceeds the maximum %g", minFPS, maxFPS)
+	}
+
+	animationMinFPS = minFPS
+	animationMaxFPS = maxFPS
+	return nil
+}
+
+// adaptiveThrottle adjusts the frame interval to what the device keeps up
+// with. Failed sends, including frames the device rejects afterwards for
+// exceeding its command quota, back off multiplicatively; sends that take
+// most of the interval stretch it just beyond the observed latency; fast
+// sends recover gradually towards the requested interval.
+type adaptiveThrottle struct {
+	mu          sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+	interval    time.Duration
+}
+
+// newAdaptiveThrottle returns a throttle that never plays faster than
+// requested, nor outside the configured fps bounds.
+func newAdaptiveThrottle(requested time.Duration) *adaptiveThrottle {
+	minInterval := max(requested, fpsToInterval(animationMaxFPS))
+	maxInterval := max(fpsToInterval(animationMinFPS), minInterval)
+	return &adaptiveThrottle{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		interval:    minInterval,
+	}
+}
+
+// Observe records how long a frame took to send and whether it failed.
+func (t *adaptiveThrottle) Observe(latency time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case err != nil:
+		t.interval *= 2
+	case latency > t.interval*4/5:
+		t.interval = latency * 5 / 4
+	default:
+		t.interval -= (t.interval - t.minInterval) / 10
+	}
+	t.interval = min(max(t.interval, t.minInterval), t.maxInterval)
+}
+
+// Interval returns the current frame interval.
+func (t *adaptiveThrottle) Interval() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.interval
+}
+
+// FPS returns the current adaptive frame rate.
+func (t *adaptiveThrottle) FPS() float64 {
+	return 1 / t.Interval().Seconds()
+}
+
+func fpsToInterval(fps float64) time.Duration {
+	return time.Duration(float64(time.Second) / fps)
+}
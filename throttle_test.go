@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// setFPSBounds sets the throttle's frame rate bounds for the duration of the
+// test.
+func setFPSBounds(t *testing.T, minFPS, maxFPS float64) {
+	t.Helper()
+	previousMin, previousMax := animationMinFPS, animationMaxFPS
+	if err := SetAnimationFPSBounds(minFPS, maxFPS); err != nil {
+		t.Fatalf("SetAnimationFPSBounds() error = %v", err)
+	}
+	t.Cleanup(func() { animationMinFPS, animationMaxFPS = previousMin, previousMax })
+}
+
+func TestAdaptiveThrottleObserve(t *testing.T) {
+	errRateLimited := errors.New("client quota exceeded")
+
+	// observation is a frame sent in latency that failed with err.
+	type observation struct {
+		latency time.Duration
+		err     error
+	}
+	fast := observation{latency: 10 * time.Millisecond}
+	limited := observation{latency: 10 * time.Millisecond, err: errRateLimited}
+
+	tests := []struct {
+		name         string
+		requested    time.Duration
+		observations []observation
+		want         time.Duration
+	}{
+		{
+			name:         "fast sends keep the requested interval",
+			requested:    100 * time.Millisecond,
+			observations: []observation{fast, fast, fast},
+			want:         100 * time.Millisecond,
+		},
+		{
+			name:         "errors back off",
+			requested:    100 * time.Millisecond,
+			observations: []observation{limited, limited},
+			want:         400 * time.Millisecond,
+		},
+		{
+			name:         "backing off stops at the minimum fps",
+			requested:    100 * time.Millisecond,
+			observations: []observation{limited, limited, limited, limited, limited, limited},
+			want:         time.Second,
+		},
+		{
+			name:         "slow sends stretch the interval beyond the latency",
+			requested:    100 * time.Millisecond,
+			observations: []observation{{latency: 160 * time.Millisecond}},
+			want:         200 * time.Millisecond,
+		},
+		{
+			name:         "fast sends recover gradually",
+			requested:    100 * time.Millisecond,
+			observations: []observation{limited, fast},
+			want:         190 * time.Millisecond,
+		},
+		{
+			name:         "requests faster than the maximum fps are clamped",
+			requested:    10 * time.Millisecond,
+			observations: []observation{fast},
+			want:         50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setFPSBounds(t, 1, 20)
+			throttle := newAdaptiveThrottle(tt.requested)
+			previous := throttle.Interval()
+			for _, o := range tt.observations {
+				throttle.Observe(o.latency, o.err)
+				if o.err != nil && throttle.Interval() < previous {
+					t.Errorf("interval dropped from %s to %s after an error", previous, throttle.Interval())
+				}
+				previous = throttle.Interval()
+			}
+			if got := throttle.Interval(); got != tt.want {
+				t.Errorf("Interval() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAnimationFPSBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		minFPS, maxFPS float64
+		wantErr        bool
+	}{
+		{name: "valid", minFPS: 0.5, maxFPS: 30},
+		{name: "equal bounds", minFPS: 5, maxFPS: 5},
+		{name: "zero minimum", minFPS: 0, maxFPS: 10, wantErr: true},
+		{name: "negative maximum", minFPS: 1, maxFPS: -10, wantErr: true},
+		{name: "infinite maximum", minFPS: 1, maxFPS: math.Inf(1), wantErr: true},
+		{name: "not a number", minFPS: math.NaN(), maxFPS: 10, wantErr: true},
+		{name: "minimum above maximum", minFPS: 20, maxFPS: 10, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousMin, previousMax := animationMinFPS, animationMaxFPS
+			t.Cleanup(func() { animationMinFPS, animationMaxFPS = previousMin, previousMax })
+
+			err := SetAnimationFPSBounds(tt.minFPS, tt.maxFPS)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetAnimationFPSBounds(%v, %v) error = %v, wantErr %v", tt.minFPS, tt.maxFPS, err, tt.wantErr)
+			}
+			wantMin, wantMax := tt.minFPS, tt.maxFPS
+			if tt.wantErr {
+				wantMin, wantMax = previousMin, previousMax
+			}
+			if animationMinFPS != wantMin || animationMaxFPS != wantMax {
+				t.Errorf("bounds = [%v, %v], want [%v, %v]", animationMinFPS, animationMaxFPS, wantMin, wantMax)
+			}
+		})
+	}
+}
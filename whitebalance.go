@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// WhiteBalance holds per-channel gain multipliers used to correct an LED
+// color cast. A gain of 1 leaves the channel unchanged.
+type WhiteBalance struct {
+	R, G, B float64
+}
+
+var NeutralWhiteBalance = WhiteBalance{R: 1, G: 1, B: 1}
+
+// Apply returns c with each channel scaled by its gain, rounded and clamped
+// to the valid range.
+func (wb WhiteBalance) Apply(c Color) Color {
+	return Color{
+		R: applyGain(c.R, wb.R),
+		G: applyGain(c.G, wb.G),
+		B: applyGain(c.B, wb.B),
+	}
+}
+
+func applyGain(v uint8, gain float64) uint8 {
+	return uint8(max(0, min(255, math.Round(float64(v)*gain))))
+}
+
+var (
+	deviceWhiteBalances = make(map[string]WhiteBalance)
+	whiteBalancesMu     sync.RWMutex
+)
+
+// LoadWhiteBalances populates the in-memory white balance registry from the
+// database so playback can apply calibration without querying it per frame.
+func LoadWhiteBalances(ctx context.Context, db *sql.DB) error {
+	balances, err := ListWhiteBalances(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to load white balances: %w", err)
+	}
+
+	whiteBalancesMu.Lock()
+	deviceWhiteBalances = balances
+	whiteBalancesMu.Unlock()
+	return nil
+}
+
+// DeviceWhiteBalance returns the calibration for the device, or
+// NeutralWhiteBalance if none was configured.
+func DeviceWhiteBalance(deviceLocation string) WhiteBalance {
+	whiteBalancesMu.RLock()
+	defer whiteBalancesMu.RUnlock()
+	if wb, ok := deviceWhiteBalances[deviceLocation]; ok {
+		return wb
+	}
+	return NeutralWhiteBalance
+}
+
+// SetDeviceWhiteBalance persists the calibration for the device and applies
+// it to subsequent frames, including those of a running animation.
+func SetDeviceWhiteBalance(ctx context.Context, db *sql.DB, deviceLocation string, wb WhiteBalance) error {
+	if err := SaveWhiteBalance(ctx, db, deviceLocation, wb); err != nil {
+		return err
+	}
+
+	whiteBalancesMu.Lock()
+	deviceWhiteBalances[deviceLocation] = wb
+	whiteBalancesMu.Unlock()
+	return nil
+}
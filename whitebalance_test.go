@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestWhiteBalanceApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		wb    WhiteBalance
+		color Color
+		want  Color
+	}{
+		{name: "neutral", wb: NeutralWhiteBalance, color: Color{R: 10, G: 20, B: 30}, want: Color{R: 10, G: 20, B: 30}},
+		{name: "halved red", wb: WhiteBalance{R: 0.5, G: 1, B: 1}, color: white, want: Color{R: 128, G: 255, B: 255}},
+		{
+			name: "gain is clamped", wb: WhiteBalance{R: 2, G: 2, B: 2},
+			color: Color{R: 100, G: 200, B: 0}, want: Color{R: 200, G: 255},
+		},
+		{name: "zero gain", wb: WhiteBalance{R: 1, G: 0, B: 1}, color: white, want: Color{R: 255, B: 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.wb.Apply(tt.color); got != tt.want {
+				t.Errorf("Apply(%+v) = %+v, want %+v", tt.color, got, tt.want)
+			}
+		})
+	}
+}